@@ -0,0 +1,82 @@
+package comb
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// crockford is the Crockford base32 alphabet, which omits I, L, O and U
+// so as to avoid confusion between characters.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// base32Len is the length of an encoded uuid, 128 bits requiring 26
+// characters of 5 bits each, the first of which only carries 3 bits.
+const base32Len = 26
+
+// crockfordDec maps a character to its 5 bit value, upper and lower case
+// letters alike, 0xff marking an illegal character.
+var crockfordDec = func() (dec [256]byte) {
+	for i := range dec {
+		dec[i] = 0xff
+	}
+	for i := 0; i < len(crockford); i++ {
+		c := crockford[i]
+		dec[c] = byte(i)
+		if c >= 'A' && c <= 'Z' {
+			dec[c+'a'-'A'] = byte(i)
+		}
+	}
+	return dec
+}()
+
+// EncodeBase32 returns id as 26 characters of Crockford base32 without
+// padding.  The 128 bits are encoded as though they were 130, the two most
+// significant being zero.
+func EncodeBase32(id uuid.UUID) string {
+	var dst [base32Len]byte
+	for i := range dst {
+		var v byte
+		for j := 0; j < 5; j++ {
+			v <<= 1
+			bit := i*5 + j - 2 // Bit offset into id.
+			if bit >= 0 && id[bit/8]&(0x80>>(bit%8)) != 0 {
+				v |= 1
+			}
+		}
+		dst[i] = crockford[v]
+	}
+	return string(dst[:])
+}
+
+// DecodeBase32 returns the uuid encoded in s by EncodeBase32, upper and
+// lower case characters are accepted.
+func DecodeBase32(s string) (uuid.UUID, error) {
+	const fname = "DecodeBase32"
+	var id uuid.UUID
+	if len(s) != base32Len {
+		return uuid.Nil, fmt.Errorf("%s: invalid length %d, want %d",
+			fname, len(s), base32Len)
+	}
+	for i := 0; i < len(s); i++ {
+		v := crockfordDec[s[i]]
+		if v == 0xff {
+			return uuid.Nil, fmt.Errorf("%s: illegal character %q at %d",
+				fname, s[i], i)
+		}
+		for j := 0; j < 5; j++ {
+			bit := i*5 + j - 2 // Bit offset into id.
+			set := v&(0x10>>j) != 0
+			if bit < 0 {
+				if set {
+					return uuid.Nil, fmt.Errorf("%s: value overflows 128 bits", fname)
+				}
+				continue
+			}
+			if set {
+				id[bit/8] |= 0x80 >> (bit % 8)
+			}
+		}
+	}
+	return id, nil
+}
@@ -0,0 +1,70 @@
+package comb
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestBase32RoundTrip(t *testing.T) {
+	max := uuid.UUID{}
+	for i := range max {
+		max[i] = 0xff
+	}
+	ids := []uuid.UUID{uuid.Nil, max}
+	for i := 0; i < 100; i++ {
+		id, err := NewTimeStampedUUID()
+		if err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		ids = append(ids, id)
+	}
+	for _, id := range ids {
+		s := EncodeBase32(id)
+		if len(s) != 26 {
+			t.Errorf("%v: want 26 characters got %d", id, len(s))
+		}
+		got, err := DecodeBase32(s)
+		if err != nil {
+			t.Errorf("%v: did not expect an error: %v", id, err)
+		}
+		if got != id {
+			t.Errorf("want %v got %v", id, got)
+		}
+		got, err = DecodeBase32(strings.ToLower(s))
+		if err != nil || got != id {
+			t.Errorf("lower case: want %v got %v %v", id, got, err)
+		}
+	}
+}
+
+func TestEncodeBase32(t *testing.T) {
+	id := uuid.MustParse("00000000-0000-0000-0000-00000000001f")
+	if s := EncodeBase32(id); s != "0000000000000000000000000Z" {
+		t.Errorf("want %q got %q", "0000000000000000000000000Z", s)
+	}
+	id = uuid.MustParse("ffffffff-ffff-ffff-ffff-ffffffffffff")
+	if s := EncodeBase32(id); s != "7ZZZZZZZZZZZZZZZZZZZZZZZZZ" {
+		t.Errorf("want %q got %q", "7ZZZZZZZZZZZZZZZZZZZZZZZZZ", s)
+	}
+}
+
+func TestDecodeBase32Invalid(t *testing.T) {
+	tests := []string{
+		"",
+		"0000000000000000000000000",
+		"000000000000000000000000000",
+		"000000000000000000000000U0",
+		"0000000000000000000000000I",
+		"000000000000000000000000L0",
+		"O0000000000000000000000000",
+		"0000000000-000000000000000",
+		"8ZZZZZZZZZZZZZZZZZZZZZZZZZ",
+	}
+	for _, s := range tests {
+		if _, err := DecodeBase32(s); err == nil {
+			t.Errorf("%q: expected an error", s)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package comb
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewBatch(t *testing.T) {
+	const n = 100
+	ids, err := NewBatch(n)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if len(ids) != n {
+		t.Fatalf("want %d got %d", n, len(ids))
+	}
+	seen := make(map[[10]byte]bool)
+	for _, id := range ids {
+		if err := Validate(id); err != nil {
+			t.Error("did not expect an error:", err)
+		}
+		if ReadTimeStamp(id) != ReadTimeStamp(ids[0]) {
+			t.Errorf("want time stamp %d got %d", ReadTimeStamp(ids[0]), ReadTimeStamp(id))
+		}
+		var r [10]byte
+		copy(r[:], id[:10])
+		if seen[r] {
+			t.Errorf("repeated random data %x", r)
+		}
+		seen[r] = true
+	}
+
+	ids, err = NewBatch(0)
+	if err != nil || len(ids) != 0 {
+		t.Errorf("want an empty batch got %v %v", ids, err)
+	}
+	if _, err = NewBatch(-1); !errors.Is(err, ErrNegativeCount) {
+		t.Errorf("want %q got %v", ErrNegativeCount, err)
+	}
+}
+
+func TestNewBatchAt(t *testing.T) {
+	const n = 50
+	when := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	ids, err := NewBatchAt(n, when)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if len(ids) != n {
+		t.Fatalf("want %d got %d", n, len(ids))
+	}
+	seen := make(map[[10]byte]bool)
+	for _, id := range ids {
+		if got := ParseTime(id); !got.Equal(when) {
+			t.Errorf("want %v got %v", when, got)
+		}
+		var r [10]byte
+		copy(r[:], id[:10])
+		if seen[r] {
+			t.Errorf("repeated random data %x", r)
+		}
+		seen[r] = true
+	}
+	if _, err = NewBatchAt(-1, when); !errors.Is(err, ErrNegativeCount) {
+		t.Errorf("want %q got %v", ErrNegativeCount, err)
+	}
+}
+
+func BenchmarkNewBatch(b *testing.B) {
+	const n = 100
+	for i := 0; i < b.N; i++ {
+		if _, err := NewBatch(n); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNewTimeStampedUUIDLoop(b *testing.B) {
+	const n = 100
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < n; j++ {
+			if _, err := NewTimeStampedUUID(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
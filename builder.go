@@ -0,0 +1,86 @@
+package comb
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// builderBatch is the number of UUIDs worth of random data that a Builder
+// reads from its io.Reader at a time.
+const builderBatch = 32
+
+// Builder generates time stamped UUIDs with a fixed configuration, caching
+// its random data and scratch space so that, on the happy path, Next does
+// not allocate.  Errors are sticky, once one has occurred Next returns
+// uuid.Nil and Err reports the error.
+//
+// A Builder is not safe for concurrent use.
+type Builder struct {
+	r       io.Reader
+	nBytes  int
+	res     time.Duration
+	rfc4122 bool
+	mask    uint64
+	buf     []byte // Random data read from r.
+	off     int    // Offset of the unused data in buf.
+	err     error
+}
+
+// NewBuilder returns a Builder that generates UUIDs as would
+// CustomTimeStampedUUID with the same arguments, stamping them with the
+// current time.
+func NewBuilder(r io.Reader, nBytes int, res time.Duration, rfc4122 bool) (*Builder, error) {
+	if err := checkWidth(nBytes, rfc4122); err != nil {
+		return nil, fmt.Errorf("NewBuilder: %w", err)
+	}
+	b := &Builder{
+		r:       r,
+		nBytes:  nBytes,
+		res:     res,
+		rfc4122: rfc4122,
+		mask:    timeStampMask(nBytes),
+		buf:     make([]byte, builderBatch*(16-nBytes)),
+	}
+	b.off = len(b.buf)
+	return b, nil
+}
+
+// Next returns the next UUID, or uuid.Nil if an error has occurred.
+func (b *Builder) Next() uuid.UUID {
+	var id uuid.UUID
+	if b.err != nil {
+		return id
+	}
+	now, _, err := uuid.GetTime()
+	if err != nil {
+		b.err = err
+		return id
+	}
+
+	nRand := len(id) - b.nBytes
+	if b.off+nRand > len(b.buf) {
+		if _, err := io.ReadFull(b.r, b.buf); err != nil {
+			b.err = readError{err}
+			return id
+		}
+		b.off = 0
+	}
+	b.off += copy(id[:nRand], b.buf[b.off:])
+	uint64ToBytes(id[nRand:], b.nBytes, toTicks(now, b.res)&b.mask)
+
+	if b.rfc4122 {
+		setRFCBits(&id)
+	}
+	return id
+}
+
+// Err returns the first error encountered by the Builder.
+func (b *Builder) Err() error {
+	if b.err == nil {
+		return nil
+	}
+	return fmt.Errorf("Builder: %w", b.err)
+}
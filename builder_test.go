@@ -0,0 +1,92 @@
+package comb
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestBuilder(t *testing.T) {
+	b, err := NewBuilder(rand.Reader, 6, time.Millisecond/10, true)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	seen := make(map[uuid.UUID]bool)
+	for i := 0; i < 3*builderBatch; i++ {
+		id := b.Next()
+		if err := Validate(id); err != nil {
+			t.Error("did not expect an error:", err)
+		}
+		if d := time.Since(ParseTime(id)); d > time.Second || d < -time.Second {
+			t.Errorf("want a time close to now got %v", ParseTime(id))
+		}
+		if seen[id] {
+			t.Errorf("repeated uuid %v", id)
+		}
+		seen[id] = true
+	}
+	if err := b.Err(); err != nil {
+		t.Error("did not expect an error:", err)
+	}
+}
+
+func TestBuilderErr(t *testing.T) {
+	if _, err := NewBuilder(rand.Reader, 8, time.Millisecond, true); err == nil {
+		t.Error("expected an error")
+	}
+
+	// Enough data for a single read of the buffer only.
+	r := bytes.NewReader(make([]byte, builderBatch*10))
+	b, err := NewBuilder(r, 6, time.Millisecond/10, true)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	for i := 0; i < builderBatch; i++ {
+		if id := b.Next(); id == uuid.Nil {
+			t.Fatalf("%d: did not expect %v", i, id)
+		}
+	}
+	if id := b.Next(); id != uuid.Nil {
+		t.Errorf("want %v got %v", uuid.Nil, id)
+	}
+	if b.Err() == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestBuilderAllocs(t *testing.T) {
+	b, err := NewBuilder(rand.Reader, 6, time.Millisecond/10, true)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if n := testing.AllocsPerRun(1000, func() { b.Next() }); n != 0 {
+		t.Errorf("want 0 allocations got %v", n)
+	}
+}
+
+func BenchmarkBuilderNext(b *testing.B) {
+	bld, err := NewBuilder(rand.Reader, 6, time.Millisecond/10, true)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		bld.Next()
+	}
+	if err := bld.Err(); err != nil {
+		b.Fatal(err)
+	}
+}
+
+func BenchmarkCustomTimeStampedUUID(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		now, _, _ := uuid.GetTime()
+		if _, err := CustomTimeStampedUUID(rand.Reader, 6, now, time.Millisecond/10, true); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package comb
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// checksumByte is the byte of the random data given over to the checksum,
+// the last before the time stamp and one free of the version and variant
+// bits.
+const checksumByte = 9
+
+// crc8 returns the CRC-8, polynomial x^8 + x^2 + x + 1, of the bytes of id
+// other than its checksum byte.  Any single bit error, and any burst of up
+// to 8 bits, changes the checksum.
+func crc8(id uuid.UUID) byte {
+	var crc byte
+	for i, b := range id {
+		if i == checksumByte {
+			continue
+		}
+		crc ^= b
+		for j := 0; j < 8; j++ {
+			if crc&0x80 != 0 {
+				crc = crc<<1 ^ 0x07
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// NewWithChecksum returns a UUID in the same layout as NewTimeStampedUUID
+// save that byte 9 holds a CRC-8 of the other 15 bytes, by which
+// VerifyChecksum may detect corruption in transit.  This leaves 65 bits
+// of random data in place of 73.
+func NewWithChecksum() (uuid.UUID, error) {
+	id, err := NewTimeStampedUUID()
+	if err != nil {
+		return id, fmt.Errorf("NewWithChecksum: %w", err)
+	}
+	id[checksumByte] = crc8(id)
+	return id, nil
+}
+
+// VerifyChecksum reports whether the checksum held in byte 9 of a UUID
+// made by NewWithChecksum matches its other bytes.
+func VerifyChecksum(id uuid.UUID) bool {
+	return id[checksumByte] == crc8(id)
+}
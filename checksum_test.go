@@ -0,0 +1,31 @@
+package comb
+
+import (
+	"testing"
+)
+
+func TestVerifyChecksum(t *testing.T) {
+	id, err := NewWithChecksum()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if !VerifyChecksum(id) {
+		t.Fatalf("%v: want a valid checksum", id)
+	}
+	if err := Validate(id); err != nil {
+		t.Error("did not expect an error:", err)
+	}
+
+	for i := range id {
+		if i == checksumByte {
+			continue
+		}
+		for bit := 0; bit < 8; bit++ {
+			flipped := id
+			flipped[i] ^= 1 << bit
+			if VerifyChecksum(flipped) {
+				t.Errorf("byte %d bit %d: want the flip detected", i, bit)
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package comb
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Clock provides the time with which a UUID is stamped, measured as is
+// uuid.Time in 100s of nano seconds since 15 Oct 1582.
+type Clock interface {
+	Now() uuid.Time
+}
+
+// DefaultClock is the source of time used by NewTimeStampedUUID, by
+// default backed by uuid.GetTime.  It may be replaced, before any UUIDs
+// are generated, so as to inject a clock.
+var DefaultClock Clock = systemClock{}
+
+// MinValidTime is the earliest time that NewTimeStampedUUID accepts from
+// DefaultClock, the Unix epoch by default, an earlier time being taken for
+// a clock that is unset or has failed, which would otherwise stamp UUIDs
+// in the 16th century.  Setting it to the zero time.Time, before any UUIDs
+// are generated, disables the check.
+var MinValidTime = time.Unix(0, 0).UTC()
+
+// defaultNow returns the time of DefaultClock, or an error wrapping
+// ErrInvalidClock should it be before MinValidTime.  The system clock is
+// read with uuid.GetTime directly so as to return its error.
+func defaultNow() (uuid.Time, error) {
+	var now uuid.Time
+	if _, ok := DefaultClock.(systemClock); ok {
+		var err error
+		if now, _, err = uuid.GetTime(); err != nil {
+			return 0, err
+		}
+	} else {
+		now = DefaultClock.Now()
+	}
+	if now < UnixToUUIDTime(MinValidTime) {
+		return 0, fmt.Errorf("%w, %v is before %v",
+			ErrInvalidClock, UUIDTimeToUnix(now), MinValidTime)
+	}
+	return now, nil
+}
+
+type systemClock struct{}
+
+// Now returns the current time as given by uuid.GetTime, which does not
+// fail once its clock sequence has been initialised.
+func (systemClock) Now() uuid.Time {
+	now, _, _ := uuid.GetTime()
+	return now
+}
+
+// NewWithClock returns a UUID in the same layout as NewTimeStampedUUID,
+// stamped with the time given by c.
+func NewWithClock(c Clock) (uuid.UUID, error) {
+	id, err := CustomTimeStampedUUID(rand.Reader, 6, c.Now(), time.Millisecond/10, true)
+	if err != nil {
+		return id, fmt.Errorf("NewWithClock: %w", err)
+	}
+	return id, nil
+}
@@ -0,0 +1,72 @@
+package comb
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FixedClock is a Clock that always returns the same time.
+type FixedClock uuid.Time
+
+func (c FixedClock) Now() uuid.Time {
+	return uuid.Time(c)
+}
+
+func TestNewWithClock(t *testing.T) {
+	// 2024-06-01T12:00:00Z in 100s of nano seconds since 15 Oct 1582.
+	const instant = FixedClock(139365360000000000)
+	id, err := NewWithClock(instant)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	want := uint64(instant) / 1000 & (1<<48 - 1)
+	if got := ReadTimeStamp(id); got != want {
+		t.Errorf("want %d got %d", want, got)
+	}
+	date := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	if got := ParseTime(id); !got.Equal(date) {
+		t.Errorf("want %v got %v", date, got)
+	}
+}
+
+func TestDefaultClock(t *testing.T) {
+	now := time.Unix(DefaultClock.Now().UnixTime())
+	if d := time.Since(now); d > time.Second || d < -time.Second {
+		t.Errorf("want a time close to now got %v", now)
+	}
+}
+
+func TestMinValidTime(t *testing.T) {
+	defer func(c Clock, minTime time.Time) { DefaultClock, MinValidTime = c, minTime }(DefaultClock, MinValidTime)
+
+	// 1969-12-31T23:59:59Z, a second before the Unix epoch.
+	DefaultClock = FixedClock(gregorianOffset - 1e7)
+	if _, err := NewTimeStampedUUID(); !errors.Is(err, ErrInvalidClock) {
+		t.Errorf("want %q got %v", ErrInvalidClock, err)
+	}
+	DefaultClock = FixedClock(0)
+	if _, err := NewTimeStampedUUID(); !errors.Is(err, ErrInvalidClock) {
+		t.Errorf("want %q got %v", ErrInvalidClock, err)
+	}
+
+	// An injected clock stamps the UUID.
+	const instant = FixedClock(139365360000000000)
+	DefaultClock = instant
+	id, err := NewTimeStampedUUID()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if got := ParseTime(id); !got.Equal(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("want the time of the clock got %v", got)
+	}
+
+	// The zero time opts out.
+	MinValidTime = time.Time{}
+	DefaultClock = FixedClock(0)
+	if _, err := NewTimeStampedUUID(); err != nil {
+		t.Error("did not expect an error:", err)
+	}
+}
@@ -0,0 +1,44 @@
+package comb
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewCompact(t *testing.T) {
+	id, err := NewCompact()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if err := Validate(id); err != nil {
+		t.Error("did not expect an error:", err)
+	}
+	if d := time.Since(ReadCompactTime(id)); d > 2*time.Second || d < -time.Second {
+		t.Errorf("want a time close to now got %v", ReadCompactTime(id))
+	}
+
+	when := time.Date(2024, 6, 1, 12, 0, 7, 0, time.UTC)
+	id, err = compact(bytes.NewReader(make([]byte, 12)), when)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if got := ReadCompactTime(id); !got.Equal(when) {
+		t.Errorf("want %v got %v", when, got)
+	}
+	if _, err := compact(bytes.NewReader(make([]byte, 12)), CompactEpoch.Add(-time.Second)); !errors.Is(err, ErrTimestampRange) {
+		t.Errorf("want %q got %v", ErrTimestampRange, err)
+	}
+
+	// 2^32 seconds, a little over 136 years.
+	if span := TimeRange(32, time.Second); span.Years != 136 {
+		t.Errorf("want 136 years got %v", span)
+	}
+	for i := 12; i < 16; i++ {
+		id[i] = 0xff
+	}
+	if got := ReadCompactTime(id); got.Year() != 2156 {
+		t.Errorf("want the last time stamp in 2156 got %v", got)
+	}
+}
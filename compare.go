@@ -0,0 +1,131 @@
+package comb
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/google/uuid"
+)
+
+// CompareByTime compares the time stamps set into two TimeStampedUUIDs,
+// returning -1 if a was stamped before b, 1 if after and 0 when both carry
+// the same time stamp.  The random data is ignored.
+func CompareByTime(a, b uuid.UUID) int {
+	return CompareByCustomTime(a, b, 6)
+}
+
+// CompareByCustomTime compares the time stamps held in the last nBytes of
+// two uuids, returning -1 if a is before b, 1 if after and 0 when they are
+// equal.
+func CompareByCustomTime(a, b uuid.UUID, nBytes int) int {
+	ta, tb := ReadCustomTimeStamp(a, nBytes), ReadCustomTimeStamp(b, nBytes)
+	switch {
+	case ta < tb:
+		return -1
+	case ta > tb:
+		return 1
+	}
+	return 0
+}
+
+// ClockWentBackwards reports whether next, generated after prev, carries
+// an earlier time stamp, as happens when the clock is stepped back, by NTP
+// or by hand, between the two.
+func ClockWentBackwards(prev, next uuid.UUID) bool {
+	return CompareByTime(next, prev) < 0
+}
+
+// SameTick reports whether two TimeStampedUUIDs carry the same time stamp,
+// having been stamped within the same 10th of a millisecond.
+func SameTick(a, b uuid.UUID) bool {
+	return SameTickWithin(a, b, 0)
+}
+
+// SameTickWithin reports whether the time stamps of two TimeStampedUUIDs
+// are no more than tolerance ticks, of a 10th of a millisecond, apart, so
+// as to group the UUIDs of a burst of events.
+func SameTickWithin(a, b uuid.UUID, tolerance uint64) bool {
+	ta, tb := ReadTimeStamp(a), ReadTimeStamp(b)
+	if ta < tb {
+		ta, tb = tb, ta
+	}
+	return ta-tb <= tolerance
+}
+
+// Key is a comparable form of a TimeStampedUUID for use in ordered maps
+// and trees, ordering first by time stamp and then by random data.
+type Key [16]byte
+
+// KeyOf returns the Key for id.
+func KeyOf(id uuid.UUID) Key {
+	return Key(id)
+}
+
+// UUID returns the uuid from which the Key was made.
+func (k Key) UUID() uuid.UUID {
+	return uuid.UUID(k)
+}
+
+// Less reports whether k sorts before other, comparing their time stamps
+// and when they are equal their random data, so that Keys are in time
+// order and yet totally ordered.
+func (k Key) Less(other Key) bool {
+	if c := CompareByTime(k.UUID(), other.UUID()); c != 0 {
+		return c < 0
+	}
+	return bytes.Compare(k[:10], other[:10]) < 0
+}
+
+// EqualIgnoringTime reports whether two TimeStampedUUIDs share their 10
+// leading bytes, their random identity, whatever their time stamps, as is
+// the case for a uuid and its restamped self.
+func EqualIgnoringTime(a, b uuid.UUID) bool {
+	return bytes.Equal(a[:10], b[:10])
+}
+
+// EqualTime reports whether two TimeStampedUUIDs carry the same time
+// stamp, whatever their random data.
+func EqualTime(a, b uuid.UUID) bool {
+	return bytes.Equal(a[10:], b[10:])
+}
+
+// ByTime implements sort.Interface for a slice of TimeStampedUUIDs,
+// ordering them as does Key.Less, by time stamp and then by random data.
+type ByTime []uuid.UUID
+
+func (s ByTime) Len() int           { return len(s) }
+func (s ByTime) Less(i, j int) bool { return KeyOf(s[i]).Less(KeyOf(s[j])) }
+func (s ByTime) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// ByTimeCustom implements sort.Interface for a slice of uuids whose time
+// stamps are held in their last NBytes, ordering them by time stamp and
+// then by the bytes that precede it.
+type ByTimeCustom struct {
+	IDs    []uuid.UUID
+	NBytes int
+}
+
+func (s ByTimeCustom) Len() int      { return len(s.IDs) }
+func (s ByTimeCustom) Swap(i, j int) { s.IDs[i], s.IDs[j] = s.IDs[j], s.IDs[i] }
+func (s ByTimeCustom) Less(i, j int) bool {
+	a, b := s.IDs[i], s.IDs[j]
+	if c := CompareByCustomTime(a, b, s.NBytes); c != 0 {
+		return c < 0
+	}
+	n := 16 - s.NBytes
+	if n < 0 {
+		n = 0
+	}
+	if n > 16 {
+		n = 16
+	}
+	return bytes.Compare(a[:n], b[:n]) < 0
+}
+
+// Distance returns |a-b|, a and b being taken as 128 bit unsigned big
+// endian integers, how far apart the two lie in the key space.
+func Distance(a, b uuid.UUID) *big.Int {
+	x := new(big.Int).SetBytes(a[:])
+	d := x.Sub(x, new(big.Int).SetBytes(b[:]))
+	return d.Abs(d)
+}
@@ -0,0 +1,250 @@
+package comb
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	mrand "math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestCompareByTime(t *testing.T) {
+	const res = time.Millisecond / 10
+	now, _, err := uuid.GetTime()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+
+	// Random data that decreases as the time increases, so that raw byte
+	// order is the reverse of the chronological order.
+	random := []byte{0xff, 0x80, 0x00}
+	ids := make([]uuid.UUID, len(random))
+	for i, b := range random {
+		r := bytes.NewReader(bytes.Repeat([]byte{b}, 10))
+		ut := now + uuid.Time(uint64(i)*ticks(res))
+		ids[i], err = CustomTimeStampedUUID(r, 6, ut, res, true)
+		if err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+	}
+	if bytes.Compare(ids[0][:], ids[1][:]) < 0 {
+		t.Fatal("expected the random bytes to reverse the order")
+	}
+
+	if c := CompareByTime(ids[0], ids[1]); c != -1 {
+		t.Errorf("want -1 got %d", c)
+	}
+	if c := CompareByTime(ids[2], ids[1]); c != 1 {
+		t.Errorf("want 1 got %d", c)
+	}
+	if c := CompareByCustomTime(ids[1], ids[1], 6); c != 0 {
+		t.Errorf("want 0 got %d", c)
+	}
+
+	got := []uuid.UUID{ids[2], ids[0], ids[1]}
+	sort.Slice(got, func(i, j int) bool {
+		return CompareByTime(got[i], got[j]) < 0
+	})
+	for i := range ids {
+		if got[i] != ids[i] {
+			t.Errorf("%d: want %v got %v", i, ids[i], got[i])
+		}
+	}
+}
+
+func TestKeyLess(t *testing.T) {
+	low := uuid.MustParse("00000000-0000-6000-e000-000000000002")
+	high := uuid.MustParse("ffffffff-ffff-6fff-ffff-000000000001")
+	sameTimeLow := uuid.MustParse("00000000-0000-6000-e000-000000000001")
+	keys := []Key{KeyOf(high), KeyOf(low), KeyOf(sameTimeLow)}
+
+	// Time stamp first, random data second.
+	if !keys[2].Less(keys[0]) {
+		t.Error("want the same time stamp ordered by random data")
+	}
+	if !keys[0].Less(keys[1]) {
+		t.Error("want the earlier time stamp first despite its random data")
+	}
+	if keys[1].Less(keys[1]) {
+		t.Error("want a key not less than itself")
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Less(keys[j]) })
+	want := []uuid.UUID{sameTimeLow, high, low}
+	for i := range want {
+		if keys[i].UUID() != want[i] {
+			t.Errorf("%d: want %v got %v", i, want[i], keys[i].UUID())
+		}
+	}
+
+	// Exactly one of a < b, b < a and a == b holds for each pair.
+	for _, a := range keys {
+		for _, b := range keys {
+			n := 0
+			if a.Less(b) {
+				n++
+			}
+			if b.Less(a) {
+				n++
+			}
+			if a == b {
+				n++
+			}
+			if n != 1 {
+				t.Errorf("%v %v: ordering is not total", a.UUID(), b.UUID())
+			}
+		}
+	}
+}
+
+func TestEqualIgnoringTime(t *testing.T) {
+	id, err := NewTimeStampedUUIDAt(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	restamped, err := RestampUUID(id, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	like, err := NewLike(id)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+
+	if !EqualIgnoringTime(id, restamped) {
+		t.Error("want a restamped uuid to share its identity")
+	}
+	if EqualTime(id, restamped) {
+		t.Error("did not want a restamped uuid to share its time")
+	}
+	if EqualIgnoringTime(id, like) {
+		t.Error("did not want a new uuid to share its identity")
+	}
+	if !EqualTime(id, like) {
+		t.Error("want a like uuid to share its time")
+	}
+}
+
+func TestByTime(t *testing.T) {
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	want := make([]uuid.UUID, 50)
+	custom := make([]uuid.UUID, 50)
+	for i := range want {
+		at := start.Add(time.Duration(i) * time.Millisecond)
+		id, err := NewTimeStampedUUIDAt(at)
+		if err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		want[i] = id
+		id, err = CustomTimeStampedUUID(rand.Reader, 8, UnixToUUIDTime(at), time.Microsecond, false)
+		if err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		custom[i] = id
+	}
+
+	r := mrand.New(mrand.NewSource(1))
+	ids := append([]uuid.UUID(nil), want...)
+	r.Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+	sort.Sort(ByTime(ids))
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Errorf("%d: want %v got %v", i, want[i], ids[i])
+		}
+	}
+
+	ids = append([]uuid.UUID(nil), custom...)
+	r.Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+	sort.Sort(ByTimeCustom{IDs: ids, NBytes: 8})
+	for i := range custom {
+		if ids[i] != custom[i] {
+			t.Errorf("%d: want %v got %v", i, custom[i], ids[i])
+		}
+	}
+
+	// Equal time stamps sort by their random data.
+	low := uuid.MustParse("00000000-0000-6000-e000-000000000001")
+	high := uuid.MustParse("ffffffff-ffff-6fff-ffff-000000000001")
+	ids = []uuid.UUID{high, low}
+	sort.Sort(ByTimeCustom{IDs: ids, NBytes: 6})
+	if ids[0] != low {
+		t.Errorf("want %v first got %v", low, ids[0])
+	}
+}
+
+func TestSameTick(t *testing.T) {
+	const tick = time.Millisecond / 10
+	when := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	a, err := NewTimeStampedUUIDAt(when)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	b, _ := NewTimeStampedUUIDAt(when)
+	next, _ := NewTimeStampedUUIDAt(when.Add(tick))
+	later, _ := NewTimeStampedUUIDAt(when.Add(3 * tick))
+
+	if !SameTick(a, b) {
+		t.Error("want the same tick for identical stamps")
+	}
+	if SameTick(a, next) || SameTick(next, a) {
+		t.Error("want different ticks one tick apart")
+	}
+	if !SameTickWithin(a, next, 1) || !SameTickWithin(next, a, 1) {
+		t.Error("want one tick apart within a tolerance of 1")
+	}
+	if SameTickWithin(a, later, 2) || !SameTickWithin(later, a, 3) {
+		t.Error("want three ticks apart outside a tolerance of 2 but within 3")
+	}
+}
+
+func TestClockWentBackwards(t *testing.T) {
+	when := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	prev, err := NewTimeStampedUUIDAt(when)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	earlier, _ := NewTimeStampedUUIDAt(when.Add(-time.Millisecond))
+	same, _ := NewTimeStampedUUIDAt(when)
+	later, _ := NewTimeStampedUUIDAt(when.Add(time.Millisecond))
+
+	if !ClockWentBackwards(prev, earlier) {
+		t.Error("want true for an earlier next")
+	}
+	if ClockWentBackwards(prev, same) {
+		t.Error("want false for the same time stamp")
+	}
+	if ClockWentBackwards(prev, later) {
+		t.Error("want false for a later next")
+	}
+}
+
+func TestDistance(t *testing.T) {
+	max := uuid.MustParse("ffffffff-ffff-ffff-ffff-ffffffffffff")
+	maxInt, _ := new(big.Int).SetString("ffffffffffffffffffffffffffffffff", 16)
+	tests := []struct {
+		a, b string
+		want *big.Int
+	}{
+		{"00000000-0000-0000-0000-000000000000", "00000000-0000-0000-0000-000000000000", big.NewInt(0)},
+		{"00000000-0000-0000-0000-000000000001", "00000000-0000-0000-0000-000000000003", big.NewInt(2)},
+		{"00000000-0000-0000-0000-000000000100", "00000000-0000-0000-0000-0000000000ff", big.NewInt(1)},
+		{"00000000-0000-0000-0000-000000010000", "00000000-0000-0000-0000-000000000000", big.NewInt(65536)},
+		// 2^64 - 1, across the halves.
+		{"00000000-0000-0001-0000-000000000000", "00000000-0000-0000-0000-000000000001", new(big.Int).SetUint64(^uint64(0))},
+		{"00000000-0000-0000-0000-000000000000", max.String(), maxInt},
+		{"00000000-0000-0000-0000-000000000001", max.String(), new(big.Int).Sub(maxInt, big.NewInt(1))},
+	}
+	for _, test := range tests {
+		a, b := uuid.MustParse(test.a), uuid.MustParse(test.b)
+		if got := Distance(a, b); got.Cmp(test.want) != 0 {
+			t.Errorf("%s %s: want %v got %v", test.a, test.b, test.want, got)
+		}
+		if got := Distance(b, a); got.Cmp(test.want) != 0 {
+			t.Errorf("%s %s: want %v got %v", test.b, test.a, test.want, got)
+		}
+	}
+}
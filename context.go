@@ -0,0 +1,55 @@
+package comb
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NewContext returns a UUID in the same layout as NewTimeStampedUUID,
+// returning ctx.Err() should the context be done before the random data
+// has been read.  The read continues in the background after the context
+// is done, its result being discarded.
+func NewContext(ctx context.Context) (uuid.UUID, error) {
+	const fname = "NewContext"
+	if err := ctx.Err(); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", fname, err)
+	}
+
+	type result struct {
+		id  uuid.UUID
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		var res result
+		_, err := io.ReadFull(rand.Reader, res.id[:10])
+		if err != nil {
+			res.err = readError{err}
+		}
+		done <- res
+	}()
+
+	select {
+	case <-ctx.Done():
+		return uuid.Nil, fmt.Errorf("%s: %w", fname, ctx.Err())
+	case res := <-done:
+		if res.err != nil {
+			return uuid.Nil, fmt.Errorf("%s: %w", fname, res.err)
+		}
+		now, _, err := uuid.GetTime()
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("%s: %w", fname, err)
+		}
+		id, err := SetTimeStamp(res.id, 6, now, time.Millisecond/10)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("%s: %w", fname, err)
+		}
+		setRFCBits(&id)
+		return id, nil
+	}
+}
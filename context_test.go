@@ -0,0 +1,29 @@
+package comb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewContext(t *testing.T) {
+	id, err := NewContext(context.Background())
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if err := Validate(id); err != nil {
+		t.Error("did not expect an error:", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	id, err = NewContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("want %q got %v", context.Canceled, err)
+	}
+	if id != uuid.Nil {
+		t.Errorf("want %v got %v", uuid.Nil, id)
+	}
+}
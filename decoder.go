@@ -0,0 +1,33 @@
+package comb
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Decoder reads the time stamps of UUIDs that share a configuration, the
+// width, resolution and epoch of their time stamps, so that they need not
+// be given for each read.  It is the counterpart of Builder.
+type Decoder struct {
+	nBytes int
+	res    time.Duration
+	epoch  uuid.Time
+}
+
+// NewDecoder returns a Decoder for time stamps of nBytes at the resolution
+// res, measured from epoch.  The zero time.Time stands for the rfc4122
+// epoch of 15 Oct 1582, the epoch of NewTimeStampedUUID.
+func NewDecoder(nBytes int, res time.Duration, epoch time.Time) *Decoder {
+	return &Decoder{nBytes: nBytes, res: res, epoch: epochTime(epoch)}
+}
+
+// Timestamp returns the raw time stamp of id.
+func (d *Decoder) Timestamp(id uuid.UUID) uint64 {
+	return ReadCustomTimeStamp(id, d.nBytes)
+}
+
+// Time returns the time of the time stamp of id.
+func (d *Decoder) Time(id uuid.UUID) time.Time {
+	return UUIDTimeToUnix(d.epoch + uuid.Time(d.Timestamp(id)*ticks(d.res)))
+}
@@ -0,0 +1,49 @@
+package comb
+
+import (
+	"crypto/rand"
+	"testing"
+	"time"
+)
+
+func TestDecoder(t *testing.T) {
+	tests := []struct {
+		nBytes int
+		res    time.Duration
+	}{
+		{6, time.Millisecond / 10},
+		{7, time.Microsecond},
+		{5, time.Second},
+	}
+	for _, test := range tests {
+		b, err := NewBuilder(rand.Reader, test.nBytes, test.res, true)
+		if err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		d := NewDecoder(test.nBytes, test.res, time.Time{})
+		want := time.Now()
+		id := b.Next()
+		if err := b.Err(); err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		if ts := d.Timestamp(id); ts != ReadCustomTimeStamp(id, test.nBytes) {
+			t.Errorf("want %d got %d", ReadCustomTimeStamp(id, test.nBytes), ts)
+		}
+		if got := d.Time(id); got.Sub(want) > test.res+time.Second || want.Sub(got) > test.res+time.Second {
+			t.Errorf("%d bytes at %v: want %v got %v", test.nBytes, test.res, want, got)
+		}
+	}
+}
+
+func TestDecoderEpoch(t *testing.T) {
+	const res = time.Millisecond
+	epoch := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	id, err := NewWithEpoch(epoch, res)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	d := NewDecoder(6, res, epoch)
+	if got, want := d.Time(id), ReadTimeStampWithEpoch(id, 6, res, epoch); !got.Equal(want) {
+		t.Errorf("want %v got %v", want, got)
+	}
+}
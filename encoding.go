@@ -0,0 +1,168 @@
+package comb
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AppendBinary appends the 16 bytes of id to dst and returns the extended
+// buffer.
+func AppendBinary(dst []byte, id uuid.UUID) []byte {
+	return append(dst, id[:]...)
+}
+
+// AppendString appends the canonical 36 character string form of id,
+// xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx, to dst and returns the extended
+// buffer.  Unlike id.String it does not allocate when dst has the room,
+// for use with the pooled buffers of a logger.
+func AppendString(dst []byte, id uuid.UUID) []byte {
+	n := len(dst)
+	dst = append(dst, make([]byte, 36)...)
+	b := dst[n:]
+	hex.Encode(b, id[:4])
+	b[8] = '-'
+	hex.Encode(b[9:13], id[4:6])
+	b[13] = '-'
+	hex.Encode(b[14:18], id[6:8])
+	b[18] = '-'
+	hex.Encode(b[19:23], id[8:10])
+	b[23] = '-'
+	hex.Encode(b[24:], id[10:])
+	return dst
+}
+
+// FromBinary returns the uuid held in b, an error being returned if b is
+// not exactly 16 bytes long or does not carry the version and variant set
+// by this package.  Unlike uuid.FromBytes the result may be trusted to hold
+// a time stamp written by this package.
+func FromBinary(b []byte) (uuid.UUID, error) {
+	const fname = "FromBinary"
+	var id uuid.UUID
+	if len(b) != len(id) {
+		return uuid.Nil, fmt.Errorf("%s: %w, length %d not %d",
+			fname, ErrInvalidUUID, len(b), len(id))
+	}
+	copy(id[:], b)
+	if err := Validate(id); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", fname, err)
+	}
+	return id, nil
+}
+
+// ToProtoBytes returns the 16 bytes of id in a newly allocated slice, for
+// a protobuf bytes field, so that the message does not alias the caller's
+// uuid.
+func ToProtoBytes(id uuid.UUID) []byte {
+	return AppendBinary(make([]byte, 0, len(id)), id)
+}
+
+// FromProtoBytes returns the uuid held in the protobuf bytes field b, as
+// does FromBinary, an error being returned if b is not exactly 16 bytes
+// long or does not carry the version and variant set by this package.
+func FromProtoBytes(b []byte) (uuid.UUID, error) {
+	id, err := FromBinary(b)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("FromProtoBytes: %w", err)
+	}
+	return id, nil
+}
+
+// FromTrimmedBytes returns the uuid held in b, a uuid from which a store
+// has stripped the trailing zero bytes, by padding b with zeros to 16
+// bytes.  As the time stamp is held in the trailing bytes it may well end
+// in zeros.  An error is returned if b is longer than 16 bytes or the
+// result does not carry the version and variant set by this package.
+func FromTrimmedBytes(b []byte) (uuid.UUID, error) {
+	const fname = "FromTrimmedBytes"
+	var id uuid.UUID
+	if len(b) > len(id) {
+		return uuid.Nil, fmt.Errorf("%s: %w, length %d exceeds %d",
+			fname, ErrInvalidUUID, len(b), len(id))
+	}
+	copy(id[:], b)
+	if err := Validate(id); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", fname, err)
+	}
+	return id, nil
+}
+
+// FromString parses the uuid s, in any of the forms accepted by
+// uuid.Parse, canonical, braced {xxxxxxxx-...}, urn:uuid:xxxxxxxx-... or
+// 32 hex digits, returning an error wrapping ErrInvalidUUID if it is
+// malformed or ErrNotComb if it does not carry the version and variant set
+// by this package.
+func FromString(s string) (uuid.UUID, error) {
+	const fname = "FromString"
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w: %v", fname, ErrInvalidUUID, err)
+	}
+	if err := Validate(id); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", fname, err)
+	}
+	return id, nil
+}
+
+// Hex returns id as 32 lower case hex digits without dashes.
+func Hex(id uuid.UUID) string {
+	return hex.EncodeToString(id[:])
+}
+
+// FromHex parses the 32 hex digits s, without dashes, in either case,
+// returning an error wrapping ErrInvalidUUID if it is malformed or
+// ErrNotComb if it does not carry the version and variant set by this
+// package.
+func FromHex(s string) (uuid.UUID, error) {
+	const fname = "FromHex"
+	var id uuid.UUID
+	if len(s) != 2*len(id) {
+		return uuid.Nil, fmt.Errorf("%s: %w, length %d not %d",
+			fname, ErrInvalidUUID, len(s), 2*len(id))
+	}
+	if _, err := hex.Decode(id[:], []byte(s)); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w: %v", fname, ErrInvalidUUID, err)
+	}
+	if err := Validate(id); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", fname, err)
+	}
+	return id, nil
+}
+
+// TimeStringFromUUID parses the TimeStampedUUID s, as does FromString,
+// and returns its decoded time stamp formatted as RFC3339Nano.  An error
+// is returned should s be malformed or not carry the version and variant
+// set by this package.
+func TimeStringFromUUID(s string) (string, error) {
+	id, err := FromString(s)
+	if err != nil {
+		return "", fmt.Errorf("TimeStringFromUUID: %w", err)
+	}
+	return ParseTime(id).Format(time.RFC3339Nano), nil
+}
+
+// StringWithTime returns the canonical string form of a TimeStampedUUID
+// followed by its decoded time stamp in parentheses, formatted as
+// RFC3339Nano.
+func StringWithTime(id uuid.UUID) string {
+	return id.String() + " (" + ParseTime(id).Format(time.RFC3339Nano) + ")"
+}
+
+// NewStorageKey returns a new TimeStampedUUID as an object storage key,
+// its decoded UTC date as a path, 2006/01/02/, followed by its Crockford
+// base32 encoding, so that keys are spread across date prefixes and list
+// by day.  DecodeBase32 recovers the UUID from the last path element.
+func NewStorageKey() (string, error) {
+	id, err := NewTimeStampedUUID()
+	if err != nil {
+		return "", fmt.Errorf("NewStorageKey: %w", err)
+	}
+	return storageKey(id), nil
+}
+
+// storageKey returns the object storage key of id.
+func storageKey(id uuid.UUID) string {
+	return ParseTime(id).Format("2006/01/02/") + EncodeBase32(id)
+}
@@ -0,0 +1,355 @@
+package comb
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	id, err := NewTimeStampedUUID()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	prefix := []byte{0xde, 0xad}
+	b := AppendBinary(prefix, id)
+	if !bytes.Equal(b[:2], prefix) || len(b) != 18 {
+		t.Fatalf("want %x followed by 16 bytes got %x", prefix, b)
+	}
+	got, err := FromBinary(b[2:])
+	if err != nil {
+		t.Error("did not expect an error:", err)
+	}
+	if got != id {
+		t.Errorf("want %v got %v", id, got)
+	}
+}
+
+func TestFromBinaryInvalid(t *testing.T) {
+	id, err := NewTimeStampedUUID()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	v4 := uuid.New()
+	tests := []struct {
+		name string
+		in   []byte
+	}{
+		{"nil", nil},
+		{"short", id[:15]},
+		{"long", append(id[:], 0)},
+		{"v4", v4[:]},
+	}
+	for _, test := range tests {
+		got, err := FromBinary(test.in)
+		if err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+		if got != uuid.Nil {
+			t.Errorf("%s: want %v got %v", test.name, uuid.Nil, got)
+		}
+	}
+
+	// Wrapped errors carry each function name once, outermost first.
+	_, err = FromBinary(v4[:])
+	if want := "FromBinary: Validate: "; err == nil || !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("want error prefixed with %q got %v", want, err)
+	}
+}
+
+func TestStringWithTime(t *testing.T) {
+	when := time.Date(2024, 6, 1, 12, 0, 0, 100000, time.UTC)
+	id, err := NewTimeStampedUUIDAt(when)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	want := id.String() + " (2024-06-01T12:00:00.0001Z)"
+	if got := StringWithTime(id); got != want {
+		t.Errorf("want %q got %q", want, got)
+	}
+}
+
+func TestFromString(t *testing.T) {
+	id, err := NewTimeStampedUUID()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	got, err := FromString(id.String())
+	if err != nil {
+		t.Error("did not expect an error:", err)
+	}
+	if got != id {
+		t.Errorf("want %v got %v", id, got)
+	}
+
+	tests := []struct {
+		in   string
+		want error
+		not  error
+	}{
+		{uuid.New().String(), ErrNotComb, ErrInvalidUUID},
+		{"01234567-89ab-6def-8123-456789abcdef", ErrNotComb, ErrInvalidUUID},
+		{"", ErrInvalidUUID, ErrNotComb},
+		{"not-a-uuid", ErrInvalidUUID, ErrNotComb},
+		{id.String()[:35], ErrInvalidUUID, ErrNotComb},
+	}
+	for _, test := range tests {
+		got, err := FromString(test.in)
+		if !errors.Is(err, test.want) || errors.Is(err, test.not) {
+			t.Errorf("%q: want %q got %v", test.in, test.want, err)
+		}
+		if got != uuid.Nil {
+			t.Errorf("%q: want %v got %v", test.in, uuid.Nil, got)
+		}
+	}
+}
+
+func TestFromStringForms(t *testing.T) {
+	id, err := NewTimeStampedUUID()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	foreign := uuid.New()
+	forms := []struct {
+		name string
+		fmt  func(uuid.UUID) string
+	}{
+		{"braced", func(id uuid.UUID) string { return "{" + id.String() + "}" }},
+		{"urn", func(id uuid.UUID) string { return id.URN() }},
+		{"hex", func(id uuid.UUID) string { return strings.ReplaceAll(id.String(), "-", "") }},
+	}
+	for _, form := range forms {
+		got, err := FromString(form.fmt(id))
+		if err != nil {
+			t.Errorf("%s: did not expect an error: %v", form.name, err)
+		}
+		if got != id {
+			t.Errorf("%s: want %v got %v", form.name, id, got)
+		}
+		var c CombUUID
+		if err := c.Scan(form.fmt(id)); err != nil || uuid.UUID(c) != id {
+			t.Errorf("%s: want %v got %v %v", form.name, id, uuid.UUID(c), err)
+		}
+
+		// Foreign uuids parse but fail validation.
+		if _, err := FromString(form.fmt(foreign)); !errors.Is(err, ErrNotComb) || errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("%s: want %q got %v", form.name, ErrNotComb, err)
+		}
+		if err := c.Scan(form.fmt(foreign)); !errors.Is(err, ErrNotComb) {
+			t.Errorf("%s: want %q got %v", form.name, ErrNotComb, err)
+		}
+	}
+	if _, err := FromString("{" + id.String()); !errors.Is(err, ErrInvalidUUID) {
+		t.Errorf("want %q got %v", ErrInvalidUUID, err)
+	}
+}
+
+func TestFromTrimmedBytes(t *testing.T) {
+	// A time stamp of zero leaves the last 6 bytes zero.
+	id, err := CustomTimeStampedUUID(bytes.NewReader(bytes.Repeat([]byte{0xaa}, 10)), 6, 0, time.Millisecond/10, true)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	for n := 10; n <= 16; n++ {
+		got, err := FromTrimmedBytes(id[:n])
+		if err != nil {
+			t.Errorf("%d bytes: did not expect an error: %v", n, err)
+		}
+		if got != id {
+			t.Errorf("%d bytes: want %v got %v", n, id, got)
+		}
+	}
+
+	if _, err := FromTrimmedBytes(append(id[:], 0)); !errors.Is(err, ErrInvalidUUID) {
+		t.Errorf("want %q got %v", ErrInvalidUUID, err)
+	}
+	if _, err := FromTrimmedBytes(id[:8]); !errors.Is(err, ErrNotComb) {
+		t.Errorf("want %q got %v", ErrNotComb, err)
+	}
+}
+
+func TestAppendString(t *testing.T) {
+	ids := []uuid.UUID{uuid.Nil, uuid.MustParse("0123abcd-4567-6def-e123-456789abcdef")}
+	for i := 0; i < 10; i++ {
+		id, err := NewTimeStampedUUID()
+		if err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		ids = append(ids, id)
+	}
+	for _, id := range ids {
+		got := AppendString([]byte("id="), id)
+		if want := "id=" + id.String(); string(got) != want {
+			t.Errorf("want %s got %s", want, got)
+		}
+	}
+
+	buf := make([]byte, 0, 64)
+	id := ids[1]
+	if n := testing.AllocsPerRun(100, func() { buf = AppendString(buf[:0], id) }); n != 0 {
+		t.Errorf("want 0 allocations got %v", n)
+	}
+}
+
+func BenchmarkAppendString(b *testing.B) {
+	id := uuid.MustParse("0123abcd-4567-6def-e123-456789abcdef")
+	buf := make([]byte, 0, 64)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = AppendString(buf[:0], id)
+	}
+}
+
+func BenchmarkString(b *testing.B) {
+	id := uuid.MustParse("0123abcd-4567-6def-e123-456789abcdef")
+	var s string
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s = id.String()
+	}
+	_ = s
+}
+
+func TestNewStorageKey(t *testing.T) {
+	key, err := NewStorageKey()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	i := strings.LastIndexByte(key, '/')
+	if i < 0 {
+		t.Fatalf("want a path got %q", key)
+	}
+	id, err := DecodeBase32(key[i+1:])
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if err := Validate(id); err != nil {
+		t.Error("did not expect an error:", err)
+	}
+	if want := ParseTime(id).Format("2006/01/02/"); key[:i+1] != want {
+		t.Errorf("want prefix %q got %q", want, key[:i+1])
+	}
+
+	when := time.Date(2024, 6, 1, 23, 59, 59, 0, time.UTC)
+	id, err = NewTimeStampedUUIDAt(when)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if got, want := storageKey(id), "2024/06/01/"+EncodeBase32(id); got != want {
+		t.Errorf("want %q got %q", want, got)
+	}
+}
+
+func TestProtoBytes(t *testing.T) {
+	id, err := NewTimeStampedUUID()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	orig := id
+	b := ToProtoBytes(id)
+	if !bytes.Equal(b, id[:]) {
+		t.Errorf("want %x got %x", id[:], b)
+	}
+
+	// Neither the slice nor the uuid alias the other.
+	b[0] ^= 0xff
+	if id != orig {
+		t.Errorf("want %v unchanged got %v", orig, id)
+	}
+	b[0] ^= 0xff
+	id[1] ^= 0xff
+	if b[1] != orig[1] {
+		t.Errorf("want byte %#02x unchanged got %#02x", orig[1], b[1])
+	}
+
+	got, err := FromProtoBytes(b)
+	if err != nil {
+		t.Error("did not expect an error:", err)
+	}
+	if got != orig {
+		t.Errorf("want %v got %v", orig, got)
+	}
+	b[0] ^= 0xff
+	if got != orig {
+		t.Errorf("want %v unchanged got %v", orig, got)
+	}
+
+	if _, err := FromProtoBytes(b[:15]); !errors.Is(err, ErrInvalidUUID) {
+		t.Errorf("want %q got %v", ErrInvalidUUID, err)
+	}
+	foreign := uuid.New()
+	if _, err := FromProtoBytes(foreign[:]); !errors.Is(err, ErrNotComb) {
+		t.Errorf("want %q got %v", ErrNotComb, err)
+	}
+}
+
+func TestTimeStringFromUUID(t *testing.T) {
+	when := time.Date(2024, 6, 1, 12, 0, 0, 123400000, time.UTC)
+	id, err := NewTimeStampedUUIDAt(when)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	got, err := TimeStringFromUUID(id.String())
+	if err != nil {
+		t.Error("did not expect an error:", err)
+	}
+	if want := "2024-06-01T12:00:00.1234Z"; got != want {
+		t.Errorf("want %s got %s", want, got)
+	}
+
+	if _, err := TimeStringFromUUID(uuid.New().String()); !errors.Is(err, ErrNotComb) {
+		t.Errorf("want %q got %v", ErrNotComb, err)
+	}
+	if _, err := TimeStringFromUUID("not-a-uuid"); !errors.Is(err, ErrInvalidUUID) {
+		t.Errorf("want %q got %v", ErrInvalidUUID, err)
+	}
+}
+
+func TestHex(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		id, err := NewTimeStampedUUID()
+		if err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		s := Hex(id)
+		if want := strings.ReplaceAll(id.String(), "-", ""); s != want {
+			t.Errorf("want %s got %s", want, s)
+		}
+		got, err := FromHex(s)
+		if err != nil {
+			t.Error("did not expect an error:", err)
+		}
+		if got != id {
+			t.Errorf("want %v got %v", id, got)
+		}
+		if got, err := FromHex(strings.ToUpper(s)); err != nil || got != id {
+			t.Errorf("want %v got %v %v", id, got, err)
+		}
+	}
+
+	valid := Hex(uuid.MustParse("0123abcd-4567-6def-e123-456789abcdef"))
+	tests := []struct {
+		in   string
+		want error
+	}{
+		{valid[:31], ErrInvalidUUID},
+		{valid + "0", ErrInvalidUUID},
+		{"", ErrInvalidUUID},
+		{"zz" + valid[2:], ErrInvalidUUID},
+		{uuid.MustParse("0123abcd-4567-6def-e123-456789abcdef").String(), ErrInvalidUUID},
+		{Hex(uuid.New()), ErrNotComb},
+	}
+	for _, test := range tests {
+		got, err := FromHex(test.in)
+		if !errors.Is(err, test.want) {
+			t.Errorf("%q: want %q got %v", test.in, test.want, err)
+		}
+		if got != uuid.Nil {
+			t.Errorf("%q: want %v got %v", test.in, uuid.Nil, got)
+		}
+	}
+}
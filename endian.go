@@ -0,0 +1,61 @@
+package comb
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// The time stamp is written big endian by SetTimeStamp, its most
+// significant byte first, which is the only order in which the byte order
+// of time stamps matches their chronological order.  For binary formats
+// that expect little endian fields the variants below write and read the
+// time stamp least significant byte first, at the cost of sortability.
+
+func uint64ToBytesLE(b []byte, n int, v uint64) {
+	_ = b[n-1] // early bounds check
+	for i := 0; i < n; i++ {
+		b[i] = byte(v >> (8 * i))
+	}
+}
+
+func bytesToUint64LE(b []byte, n int) uint64 {
+	_ = b[n-1] // early bounds check
+	var val uint64
+	for i := 0; i < n; i++ {
+		val |= uint64(b[i]) << (8 * i)
+	}
+	return val
+}
+
+// SetTimeStampLE writes the time t as does SetTimeStamp, little endian.
+func SetTimeStampLE(id uuid.UUID, nBytes int, t uuid.Time, res time.Duration) (uuid.UUID, error) {
+	if nBytes > maxTimeStampBytes {
+		return id, fmt.Errorf("%w, %d exceeds %d", ErrTooManyBytes, nBytes, maxTimeStampBytes)
+	}
+	if nBytes < 1 {
+		return id, fmt.Errorf("%w, %d given at least 1 is required", ErrTooFewBytes, nBytes)
+	}
+	mask := timeStampMask(nBytes)
+	uint64ToBytesLE(id[len(id)-nBytes:], nBytes, toTicks(t, res)&mask)
+	return id, nil
+}
+
+// ReadTimeStampLE reads the 6 byte little endian time stamp written by
+// SetTimeStampLE.
+func ReadTimeStampLE(id uuid.UUID) uint64 {
+	return ReadCustomTimeStampLE(id, 6)
+}
+
+// ReadCustomTimeStampLE reads the last nBytes of the uuid as a little
+// endian integer, nBytes being limited as for ReadCustomTimeStamp.
+func ReadCustomTimeStampLE(id uuid.UUID, nBytes int) uint64 {
+	if nBytes < 1 {
+		return 0
+	}
+	if nBytes > maxTimeStampBytes {
+		nBytes = maxTimeStampBytes
+	}
+	return bytesToUint64LE(id[len(id)-nBytes:], nBytes)
+}
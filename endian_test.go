@@ -0,0 +1,42 @@
+package comb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestTimeStampLE(t *testing.T) {
+	const want = uint64(0x0123456789ab)
+	const res = time.Millisecond / 10
+	ut := uuid.Time(want * 1000)
+
+	be, err := SetTimeStamp(uuid.Nil, 6, ut, res)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	le, err := SetTimeStampLE(uuid.Nil, 6, ut, res)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if s := le.String(); s != "00000000-0000-0000-0000-ab8967452301" {
+		t.Errorf("want %q got %q", "00000000-0000-0000-0000-ab8967452301", s)
+	}
+	if be == le {
+		t.Error("want the byte layouts to differ")
+	}
+	if got := ReadTimeStamp(be); got != want {
+		t.Errorf("want %#x got %#x", want, got)
+	}
+	if got := ReadTimeStampLE(le); got != want {
+		t.Errorf("want %#x got %#x", want, got)
+	}
+	if got := ReadCustomTimeStampLE(le, 9); got != ReadCustomTimeStampLE(le, 8) {
+		t.Errorf("want %#x got %#x", ReadCustomTimeStampLE(le, 8), got)
+	}
+
+	if _, err := SetTimeStampLE(uuid.Nil, 9, ut, res); err == nil {
+		t.Error("expected an error")
+	}
+}
@@ -0,0 +1,52 @@
+package comb
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NewWithEpoch returns a UUID with 6 bytes of time stamp, measured at the
+// resolution res from epoch rather than from 15 Oct 1582, the remaining
+// bytes being cryptographically random with the version and variant set
+// as for NewTimeStampedUUID.  By not spending its range on the centuries
+// before epoch a time stamp may cover more of the future, or be stamped
+// at a finer resolution.  The time stamp can only be read back given the
+// same epoch, by ReadTimeStampWithEpoch.  The zero time.Time stands for
+// the rfc4122 epoch of 15 Oct 1582, as it does for NewDecoder.
+func NewWithEpoch(epoch time.Time, res time.Duration) (uuid.UUID, error) {
+	const fname = "NewWithEpoch"
+	now, _, err := uuid.GetTime()
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", fname, err)
+	}
+	start := epochTime(epoch)
+	if now < start {
+		return uuid.Nil, fmt.Errorf("%s: %w, epoch %v is in the future",
+			fname, ErrTimestampRange, epoch)
+	}
+	id, err := CustomTimeStampedUUID(rand.Reader, 6, now-start, res, true)
+	if err != nil {
+		return id, fmt.Errorf("%s: %w", fname, err)
+	}
+	return id, nil
+}
+
+// ReadTimeStampWithEpoch returns the time held in the last nBytes of the
+// uuid, measured at the resolution res from epoch, the zero time.Time
+// standing for 15 Oct 1582.
+func ReadTimeStampWithEpoch(id uuid.UUID, nBytes int, res time.Duration, epoch time.Time) time.Time {
+	t := uuid.Time(ReadCustomTimeStamp(id, nBytes) * ticks(res))
+	return UUIDTimeToUnix(epochTime(epoch) + t)
+}
+
+// epochTime returns epoch as a uuid.Time, the zero time.Time standing for
+// the rfc4122 epoch of 15 Oct 1582, uuid.Time 0, rather than the year 1.
+func epochTime(epoch time.Time) uuid.Time {
+	if epoch.IsZero() {
+		return 0
+	}
+	return UnixToUUIDTime(epoch)
+}
@@ -0,0 +1,71 @@
+package comb
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewWithEpoch(t *testing.T) {
+	const res = time.Millisecond
+	epoch := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	want := time.Now()
+	id, err := NewWithEpoch(epoch, res)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if err := Validate(id); err != nil {
+		t.Error("did not expect an error:", err)
+	}
+	got := ReadTimeStampWithEpoch(id, 6, res, epoch)
+	if d := got.Sub(want); d > time.Second || d < -time.Second {
+		t.Errorf("want %v got %v", want, got)
+	}
+
+	// Read from the wrong epoch the time is out by the difference between
+	// the two.
+	wrong := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	offset := ReadTimeStampWithEpoch(id, 6, res, wrong)
+	if d := offset.Sub(got); d != wrong.Sub(epoch) {
+		t.Errorf("want an offset of %v got %v", wrong.Sub(epoch), d)
+	}
+
+	// From the rfc4122 epoch the time stamp is meaningless.
+	if y := ParseCustomTime(id, 6, res).Year(); y > 1600 {
+		t.Errorf("want a time in the 16th century got %v", ParseCustomTime(id, 6, res))
+	}
+}
+
+func TestNewWithEpochFuture(t *testing.T) {
+	_, err := NewWithEpoch(time.Now().Add(time.Hour), time.Millisecond)
+	if !errors.Is(err, ErrTimestampRange) {
+		t.Errorf("want %q got %v", ErrTimestampRange, err)
+	}
+}
+
+func TestZeroEpoch(t *testing.T) {
+	when := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	id, err := NewTimeStampedUUIDAt(when)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	// The zero epoch means 15 Oct 1582 to every API that takes one.
+	const res = time.Millisecond / 10
+	if got := ReadTimeStampWithEpoch(id, 6, res, time.Time{}); !got.Equal(when) {
+		t.Errorf("want %v got %v", when, got)
+	}
+	if got := NewDecoder(6, res, time.Time{}).Time(id); !got.Equal(when) {
+		t.Errorf("want %v got %v", when, got)
+	}
+	if a, b := ReadTimeStampWithEpoch(id, 6, res, time.Time{}), NewDecoder(6, res, time.Time{}).Time(id); !a.Equal(b) {
+		t.Errorf("want the same time from both got %v and %v", a, b)
+	}
+
+	id, err = NewWithEpoch(time.Time{}, res)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if d := time.Since(ParseTime(id)); d > time.Second || d < -time.Second {
+		t.Errorf("want the layout of NewTimeStampedUUID got %v", ParseTime(id))
+	}
+}
@@ -0,0 +1,74 @@
+package comb
+
+import (
+	"errors"
+)
+
+// Errors returned by the package, wrapped so as to give their context,
+// which callers may match with errors.Is.
+var (
+	// ErrTooManyBytes is returned when a time stamp is wider than the
+	// uuid, a uint64 or the space remaining beside the version and
+	// variant bits allows.
+	ErrTooManyBytes = errors.New("too many bytes to format")
+
+	// ErrTooFewBytes is returned when a time stamp of less than 1 byte is
+	// requested.
+	ErrTooFewBytes = errors.New("too few bytes to format")
+
+	// ErrTimestampRange is returned when a time stamp can not be held in
+	// the bytes available to it without wrapping.
+	ErrTimestampRange = errors.New("time stamp out of range")
+
+	// ErrNegativeCount is returned when a negative number of UUIDs is
+	// requested.
+	ErrNegativeCount = errors.New("negative count")
+
+	// ErrShortRead is returned when the random data can not be read in
+	// full, the error returned by the io.Reader is also wrapped.
+	ErrShortRead = errors.New("short read of random data")
+
+	// ErrInvalidUUID is returned when a uuid can not be decoded.
+	ErrInvalidUUID = errors.New("invalid uuid")
+
+	// ErrNotComb is returned when a uuid does not carry the version and
+	// variant set by this package.
+	ErrNotComb = errors.New("not a comb uuid")
+
+	// ErrBadEntropy is returned by CheckEntropy when a source of random
+	// data appears not to be random.
+	ErrBadEntropy = errors.New("random data source looks broken")
+
+	// ErrClosed is returned by a generator that has been closed.
+	ErrClosed = errors.New("generator closed")
+
+	// ErrUnknownVariant is returned when a Variant is not one of those
+	// defined by the package.
+	ErrUnknownVariant = errors.New("unknown variant")
+
+	// ErrUnknownEncoding is returned when no Encoding is registered under
+	// the name given.
+	ErrUnknownEncoding = errors.New("unknown encoding")
+
+	// ErrInvalidClock is returned when the clock gives a time before
+	// MinValidTime.
+	ErrInvalidClock = errors.New("clock before the minimum valid time")
+)
+
+// readError wraps an error returned whilst reading random data so that it
+// matches both ErrShortRead and the underlying error.
+type readError struct {
+	err error
+}
+
+func (e readError) Error() string {
+	return ErrShortRead.Error() + ": " + e.err.Error()
+}
+
+func (e readError) Unwrap() error {
+	return e.err
+}
+
+func (e readError) Is(target error) bool {
+	return target == ErrShortRead
+}
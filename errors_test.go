@@ -0,0 +1,52 @@
+package comb
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestErrors(t *testing.T) {
+	const res = time.Millisecond / 10
+	_, shortErr := CustomTimeStampedUUID(bytes.NewReader(make([]byte, 3)), 6, 0, res, true)
+	_, wideErr := CustomTimeStampedUUID(rand.Reader, 9, 0, res, false)
+	_, narrowErr := CustomTimeStampedUUID(rand.Reader, 0, 0, res, false)
+	_, setErr := SetTimeStamp(uuid.Nil, 17, 0, res)
+	_, rangeErr := SetTimeStampStrict(uuid.Nil, 1, uuid.Time(256*ticks(res)), res)
+	combErr := Validate(uuid.New())
+
+	tests := []struct {
+		name string
+		err  error
+		is   []error
+		not  []error
+	}{
+		{"short read", shortErr, []error{ErrShortRead, io.ErrUnexpectedEOF}, []error{ErrTooManyBytes}},
+		{"too wide", wideErr, []error{ErrTooManyBytes}, []error{ErrShortRead, ErrTooFewBytes}},
+		{"too narrow", narrowErr, []error{ErrTooFewBytes}, []error{ErrTooManyBytes}},
+		{"set", setErr, []error{ErrTooManyBytes}, []error{ErrTimestampRange}},
+		{"range", rangeErr, []error{ErrTimestampRange}, []error{ErrTooManyBytes}},
+		{"not comb", combErr, []error{ErrNotComb}, []error{ErrShortRead}},
+	}
+	for _, test := range tests {
+		if test.err == nil {
+			t.Errorf("%s: expected an error", test.name)
+			continue
+		}
+		for _, target := range test.is {
+			if !errors.Is(test.err, target) {
+				t.Errorf("%s: want %q to match %q", test.name, test.err, target)
+			}
+		}
+		for _, target := range test.not {
+			if errors.Is(test.err, target) {
+				t.Errorf("%s: did not want %q to match %q", test.name, test.err, target)
+			}
+		}
+	}
+}
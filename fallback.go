@@ -0,0 +1,43 @@
+package comb
+
+import (
+	"fmt"
+	"io"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+)
+
+// fallbacks counts the UUIDs made by NewWithFallback from its fallback.
+var fallbacks uint64
+
+// NewWithFallback returns a UUID in the same layout as NewTimeStampedUUID
+// whose random data is read from primary or, should that fail, from
+// fallback, each use of the fallback being counted by FallbackCount.  A
+// UUID is only as unpredictable as the source it was read from, and the
+// failure of primary passes unnoticed but for the count, so that fallback
+// should be a cryptographically secure source in its own right, never a
+// math/rand source where the UUIDs must not be guessed.
+func NewWithFallback(primary, fallback io.Reader) (uuid.UUID, error) {
+	const fname = "NewWithFallback"
+	now, _, err := uuid.GetTime()
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", fname, err)
+	}
+	var id uuid.UUID
+	perr := Fill(&id, primary, now)
+	if perr == nil {
+		return id, nil
+	}
+	if err := Fill(&id, fallback, now); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w, the primary having failed: %v", fname, err, perr)
+	}
+	atomic.AddUint64(&fallbacks, 1)
+	return id, nil
+}
+
+// FallbackCount returns the number of UUIDs that NewWithFallback has made
+// from its fallback source since the program started.
+func FallbackCount() uint64 {
+	return atomic.LoadUint64(&fallbacks)
+}
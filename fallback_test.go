@@ -0,0 +1,50 @@
+package comb
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+)
+
+type errReader struct{ err error }
+
+func (r errReader) Read(p []byte) (int, error) {
+	return 0, r.err
+}
+
+func TestNewWithFallback(t *testing.T) {
+	broken := errReader{errors.New("entropy unavailable")}
+
+	before := FallbackCount()
+	id, err := NewWithFallback(rand.Reader, broken)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if err := Validate(id); err != nil {
+		t.Error("did not expect an error:", err)
+	}
+	if n := FallbackCount() - before; n != 0 {
+		t.Errorf("want no use of the fallback got %d", n)
+	}
+
+	random := bytes.Repeat([]byte{0x11}, 10)
+	id, err = NewWithFallback(broken, bytes.NewReader(random))
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if id[0] != 0x11 {
+		t.Errorf("want random data from the fallback got %v", id)
+	}
+	if n := FallbackCount() - before; n != 1 {
+		t.Errorf("want 1 use of the fallback got %d", n)
+	}
+
+	_, err = NewWithFallback(broken, broken)
+	if !errors.Is(err, ErrShortRead) {
+		t.Errorf("want %q got %v", ErrShortRead, err)
+	}
+	if n := FallbackCount() - before; n != 1 {
+		t.Errorf("want 1 use of the fallback got %d", n)
+	}
+}
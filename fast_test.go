@@ -0,0 +1,58 @@
+package comb
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewFast(t *testing.T) {
+	const workers, n = 8, 1000
+	var mu sync.Mutex
+	seen := make(map[[10]byte]bool)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				id, err := NewFast()
+				if err != nil {
+					t.Error("did not expect an error:", err)
+					return
+				}
+				if err := Validate(id); err != nil {
+					t.Error("did not expect an error:", err)
+				}
+				if d := time.Since(ParseTime(id)); d > time.Second || d < -time.Second {
+					t.Errorf("want a time close to now got %v", ParseTime(id))
+				}
+				var r [10]byte
+				copy(r[:], id[:10])
+				mu.Lock()
+				seen[r] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if len(seen) != workers*n {
+		t.Errorf("want %d distinct random prefixes got %d", workers*n, len(seen))
+	}
+}
+
+func BenchmarkNewFast(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := NewFast(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNewTimeStampedUUID(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := NewTimeStampedUUID(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
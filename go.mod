@@ -2,4 +2,4 @@ module github.com/8i8/comb
 
 go 1.18
 
-require github.com/google/uuid v1.3.0 // indirect
+require github.com/google/uuid v1.3.0
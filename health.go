@@ -0,0 +1,55 @@
+package comb
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+// entropySample is the number of bytes read by CheckEntropy.
+const entropySample = 256
+
+// CheckEntropy reads a sample from r and reports whether it looks like
+// random data, returning an error wrapping ErrBadEntropy should every
+// byte be the same, too few distinct byte values appear, the sample
+// repeat itself or its bits be far from evenly balanced between ones and
+// zeros.  This is a heuristic that catches a stuck or zeroed source, it
+// is not a statistical test of randomness, data that passes may still be
+// predictable.
+func CheckEntropy(r io.Reader) error {
+	const fname = "CheckEntropy"
+	var b [entropySample]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return fmt.Errorf("%s: %w", fname, readError{err})
+	}
+
+	var seen [256]bool
+	distinct, ones := 0, 0
+	for _, c := range b {
+		if !seen[c] {
+			seen[c] = true
+			distinct++
+		}
+		ones += bits.OnesCount8(c)
+	}
+
+	switch {
+	case distinct == 1:
+		return fmt.Errorf("%s: %w, every byte is %#02x", fname, ErrBadEntropy, b[0])
+	case distinct < entropySample/4:
+		// A random sample has some 160 distinct values.
+		return fmt.Errorf("%s: %w, %d distinct byte values in %d",
+			fname, ErrBadEntropy, distinct, entropySample)
+	case bytes.Equal(b[:entropySample/2], b[entropySample/2:]):
+		return fmt.Errorf("%s: %w, the sample repeats", fname, ErrBadEntropy)
+	}
+	// Half of the 2048 bits are expected to be set, give or take some 23,
+	// the bound lies near 9 standard deviations.
+	const half, bound = entropySample * 4, 200
+	if ones < half-bound || ones > half+bound {
+		return fmt.Errorf("%s: %w, %d of %d bits set",
+			fname, ErrBadEntropy, ones, entropySample*8)
+	}
+	return nil
+}
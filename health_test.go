@@ -0,0 +1,59 @@
+package comb
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"math/bits"
+	"testing"
+)
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
+
+func TestCheckEntropy(t *testing.T) {
+	if err := CheckEntropy(rand.Reader); err != nil {
+		t.Error("did not expect an error:", err)
+	}
+
+	counter := make([]byte, entropySample)
+	for i := range counter {
+		counter[i] = byte(i % 16)
+	}
+	pattern := make([]byte, entropySample)
+	rand.Read(pattern[:entropySample/2])
+	copy(pattern[entropySample/2:], pattern)
+	// Bytes with 5 or more bits set, many values yet biased.
+	var heavy []byte
+	for i := 0; len(heavy) < entropySample; i++ {
+		if c := byte(i); bits.OnesCount8(c) >= 5 {
+			heavy = append(heavy, c)
+		}
+	}
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"zeros", make([]byte, entropySample)},
+		{"few values", counter},
+		{"repeating", pattern},
+		{"biased", heavy},
+	}
+	for _, test := range tests {
+		if err := CheckEntropy(bytes.NewReader(test.data)); !errors.Is(err, ErrBadEntropy) {
+			t.Errorf("%s: want ErrBadEntropy got %v", test.name, err)
+		}
+	}
+	if err := CheckEntropy(zeroReader{}); !errors.Is(err, ErrBadEntropy) {
+		t.Errorf("want ErrBadEntropy got %v", err)
+	}
+	if err := CheckEntropy(bytes.NewReader(make([]byte, 10))); !errors.Is(err, ErrShortRead) {
+		t.Errorf("want ErrShortRead got %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package comb
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Info describes the fields of a TimeStampedUUID.
+type Info struct {
+	Version   int
+	Variant   string
+	Timestamp time.Time
+	Raw       uint64
+	IsComb    bool
+}
+
+// Inspect decodes the version, variant and time stamp of id.  The time
+// stamp is decoded whether or not id was produced by this package, IsComb
+// reports whether it was and so whether the time stamp is meaningful.
+func Inspect(id uuid.UUID) Info {
+	return Info{
+		Version:   int(id.Version()),
+		Variant:   id.Variant().String(),
+		Timestamp: ParseTime(id),
+		Raw:       ReadTimeStamp(id),
+		IsComb:    IsCombUUID(id),
+	}
+}
+
+// DebugBits returns a breakdown of id, one line per byte giving its value
+// in hex and binary and the field that it holds in the layout of
+// NewTimeStampedUUID, followed by the decoded version, variant and time
+// stamp.  It is a diagnostic aid, see Inspect for the decoded fields.
+func DebugBits(id uuid.UUID) string {
+	info := Inspect(id)
+	var b strings.Builder
+	for i, c := range id {
+		field := "random"
+		switch {
+		case i == 6:
+			field = fmt.Sprintf("version %d (high nibble), random", info.Version)
+		case i == 8:
+			field = fmt.Sprintf("variant %s (high bits), random", info.Variant)
+		case i >= 10:
+			field = "time stamp"
+		}
+		fmt.Fprintf(&b, "byte %2d  %#02x  %08b  %s\n", i, c, c, field)
+	}
+	fmt.Fprintf(&b, "version %d, variant %s, comb %t\n", info.Version, info.Variant, info.IsComb)
+	fmt.Fprintf(&b, "time stamp %d, %s\n", info.Raw, info.Timestamp.Format(time.RFC3339Nano))
+	return b.String()
+}
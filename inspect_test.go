@@ -0,0 +1,62 @@
+package comb
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestInspect(t *testing.T) {
+	when := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	id, err := NewTimeStampedUUIDAt(when)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	want := Info{
+		Version:   6,
+		Variant:   "Future",
+		Timestamp: when,
+		Raw:       uint64(UnixToUUIDTime(when)) / 1000 & (1<<48 - 1),
+		IsComb:    true,
+	}
+	if got := Inspect(id); got != want {
+		t.Errorf("want %+v got %+v", want, got)
+	}
+
+	v4 := uuid.New()
+	got := Inspect(v4)
+	if got.Version != 4 || got.Variant != "RFC4122" || got.IsComb {
+		t.Errorf("want a version 4 RFC4122 uuid got %+v", got)
+	}
+}
+
+func TestDebugBits(t *testing.T) {
+	when := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	id, err := NewTimeStampedUUIDAt(when)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	out := DebugBits(id)
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if len(lines) != 18 {
+		t.Fatalf("want 18 lines got %d:\n%s", len(lines), out)
+	}
+	for _, want := range []string{
+		"2024-06-01T12:00:00Z",
+		"version 6 (high nibble)",
+		"variant Future (high bits)",
+		"comb true",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("want %q in:\n%s", want, out)
+		}
+	}
+	if !strings.HasSuffix(lines[15], "time stamp") || !strings.HasSuffix(lines[0], "random") {
+		t.Errorf("want the fields labelled got:\n%s", out)
+	}
+	if !strings.Contains(DebugBits(uuid.New()), "version 4") {
+		t.Error("want a version 4 label for a random uuid")
+	}
+}
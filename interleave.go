@@ -0,0 +1,68 @@
+package comb
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// interleavedStamp holds the positions of the 6 time stamp bytes of an
+// interleaved UUID, most significant first, and interleavedRand those of
+// its 10 random bytes, bytes 6 and 8 carrying the version and variant.
+var (
+	interleavedStamp = [6]int{1, 3, 5, 10, 12, 14}
+	interleavedRand  = [10]int{0, 2, 4, 6, 7, 8, 9, 11, 13, 15}
+)
+
+// NewInterleaved returns a UUID holding the same 6 byte time stamp and 73
+// bits of random data as NewTimeStampedUUID, but with the time stamp bytes
+// spread between the random bytes so that the time at which it was made
+// is not so easily read from its string form.  This is obfuscation, not
+// encryption, anyone who knows the layout can read the time stamp with
+// ReadInterleavedTimeStamp.  Interleaved UUIDs do not sort by time.
+func NewInterleaved() (uuid.UUID, error) {
+	const fname = "NewInterleaved"
+	now, _, err := uuid.GetTime()
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", fname, err)
+	}
+	id, err := interleaved(rand.Reader, now)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", fname, err)
+	}
+	return id, nil
+}
+
+// interleaved returns an interleaved UUID stamped with the time t, its
+// random data being read from r.
+func interleaved(r io.Reader, t uuid.Time) (uuid.UUID, error) {
+	var id uuid.UUID
+	var b [10]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return uuid.Nil, readError{err}
+	}
+	for i, p := range interleavedRand {
+		id[p] = b[i]
+	}
+
+	var ts [6]byte
+	uint64ToBytes(ts[:], 6, toTicks(t, time.Millisecond/10))
+	for i, p := range interleavedStamp {
+		id[p] = ts[i]
+	}
+	setRFCBits(&id)
+	return id, nil
+}
+
+// ReadInterleavedTimeStamp returns the time stamp of a UUID made by
+// NewInterleaved, in the units of ReadTimeStamp.
+func ReadInterleavedTimeStamp(id uuid.UUID) uint64 {
+	var ts [6]byte
+	for i, p := range interleavedStamp {
+		ts[i] = id[p]
+	}
+	return bytesToUint64(ts[:], 6)
+}
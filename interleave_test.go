@@ -0,0 +1,52 @@
+package comb
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestNewInterleaved(t *testing.T) {
+	id, err := NewInterleaved()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if err := Validate(id); err != nil {
+		t.Error("did not expect an error:", err)
+	}
+	now := toTicks(UnixToUUIDTime(time.Now()), time.Millisecond/10)
+	if d := now - ReadInterleavedTimeStamp(id); d > 10000 {
+		t.Errorf("want a time stamp close to %d got %d", now, ReadInterleavedTimeStamp(id))
+	}
+}
+
+func TestInterleavedLayout(t *testing.T) {
+	const res = time.Millisecond / 10
+	when := UnixToUUIDTime(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	random := bytes.Repeat([]byte{0x55}, 10)
+	id, err := interleaved(bytes.NewReader(random), when)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	std, err := CustomTimeStampedUUID(bytes.NewReader(random), 6, when, res, true)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+
+	if got, want := ReadInterleavedTimeStamp(id), ReadTimeStamp(std); got != want {
+		t.Errorf("want %d got %d", want, got)
+	}
+	if id == std {
+		t.Errorf("want a layout differing from %v", std)
+	}
+	if bytes.Equal(id[10:], std[10:]) {
+		t.Errorf("want the time stamp moved got %x", id[10:])
+	}
+	if id[6] != std[6] || id[8] != std[8] {
+		t.Errorf("want the version and variant of %v got %v", std, id)
+	}
+
+	if _, err := interleaved(bytes.NewReader(random[:5]), when); err == nil {
+		t.Error("expected an error on a short read")
+	}
+}
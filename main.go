@@ -8,10 +8,10 @@ package comb
 
 import (
 	"crypto/rand"
-	"errors"
 	"fmt"
 	"io"
 	"math"
+	"math/big"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,28 +19,23 @@ import (
 
 const pkg = "comb"
 
-// NullUUID mimics the behaviour of the sql.Null* types
-type NullUUID struct {
-	uuid.UUID
-	Valid bool
-}
-
 func uint64ToBytes(b []byte, n int, v uint64) {
 	_ = b[n-1] // early bounds check
 	for i := 0; i < n; i++ {
-		b[i] = byte(v >> (1 << (n - 1 - i)))
+		b[i] = byte(v >> (8 * (n - 1 - i)))
 	}
 }
 
 func bytesToUint64(b []byte, n int) uint64 {
-	lenInt := 8
-	_ = b[n-1]           // early bounds check
-	if len(b) > lenInt { // 8 bytes maximum.
+	const maxBytes = 8     // Bytes in a uint64.
+	const bitsPerByte = 8  // Bits in a byte.
+	_ = b[n-1]             // early bounds check
+	if len(b) > maxBytes { // 8 bytes maximum.
 		panic("byte slice is larger than uint64")
 	}
 	var val uint64
 	for i := 0; i < n; i++ {
-		val |= uint64(b[n-1-i]) << (i * lenInt)
+		val |= uint64(b[n-1-i]) << (i * bitsPerByte)
 	}
 	return val
 }
@@ -51,45 +46,334 @@ func ReadTimeStamp(id uuid.UUID) uint64 {
 }
 
 // ReadCustomTimeStamp reads n bytes from the least significant bit of
-// the uuid and returns the value contained there as an integer.
+// the uuid and returns the value contained there as an integer.  As no
+// more than 8 bytes fit into a uint64, a larger nBytes reads only the last
+// 8, the bytes in which SetTimeStamp writes the value, and an nBytes of
+// less than 1 reads nothing and returns 0.
 func ReadCustomTimeStamp(id uuid.UUID, nBytes int) uint64 {
+	if nBytes < 1 {
+		return 0
+	}
+	if nBytes > maxTimeStampBytes {
+		nBytes = maxTimeStampBytes
+	}
 	return bytesToUint64(id[len(id)-nBytes:], nBytes)
 }
 
+// ReadTimeStampAt reads the nBytes of the uuid that begin at byte start,
+// big endian, and returns the value contained there as an integer, for
+// layouts that keep their time stamp away from the end of the uuid.  An
+// error is returned should nBytes not be between 1 and 8 or the bytes run
+// past either end of the uuid.
+func ReadTimeStampAt(id uuid.UUID, start, nBytes int) (uint64, error) {
+	const fname = "ReadTimeStampAt"
+	if err := checkWidth(nBytes, false); err != nil {
+		return 0, fmt.Errorf("%s: %w", fname, err)
+	}
+	if start < 0 || start > len(id)-nBytes {
+		return 0, fmt.Errorf("%s: %w, %d bytes from %d overrun the uuid",
+			fname, ErrTooManyBytes, nBytes, start)
+	}
+	return bytesToUint64(id[start:start+nBytes], nBytes), nil
+}
+
+// Split returns a copy of the 10 leading bytes of a TimeStampedUUID, its
+// random data, along with its time stamp.  Note that of the random bytes,
+// the high nibble of byte 6 holds the version and the 3 high bits of byte 8
+// the variant, neither of which are random.
+func Split(id uuid.UUID) (random []byte, timestamp uint64) {
+	return SplitCustom(id, 6)
+}
+
+// SplitCustom returns a copy of the leading bytes of the uuid that precede
+// its nBytes of time stamp, along with the time stamp itself.  nBytes is
+// clamped to between 0 and 8 as for ReadCustomTimeStamp, so that between
+// them the two values always hold every byte of the uuid, an nBytes of
+// less than 1 giving all 16 bytes and a time stamp of 0, one of more than
+// 8 the leading 8 bytes and the time stamp of the last 8.
+func SplitCustom(id uuid.UUID, nBytes int) (random []byte, timestamp uint64) {
+	if nBytes < 0 {
+		nBytes = 0
+	}
+	if nBytes > maxTimeStampBytes {
+		nBytes = maxTimeStampBytes
+	}
+	random = make([]byte, len(id)-nBytes)
+	copy(random, id[:])
+	return random, ReadCustomTimeStamp(id, nBytes)
+}
+
+// ParseTime returns the time that is set into a TimeStampedUUID.
+func ParseTime(id uuid.UUID) time.Time {
+	return ParseCustomTime(id, 6, time.Millisecond/10)
+}
+
+// Fraction returns the part of the time stamp of a TimeStampedUUID below
+// one second, to the 10th of a millisecond at which it was written.
+func Fraction(id uuid.UUID) time.Duration {
+	return time.Duration(ParseTime(id).Nanosecond())
+}
+
+// UnixMilli returns the time stamp of a TimeStampedUUID as the number of
+// milliseconds since the Unix epoch, as used by JavaScript, the 10th of a
+// millisecond being truncated.
+func UnixMilli(id uuid.UUID) int64 {
+	return ParseTime(id).UnixMilli()
+}
+
+// ParseCustomTime reads the time stamp from the last nBytes of the uuid
+// and converts it, using the resolution with which it was written, back
+// into a time.Time.  The value returned is only as precise as the given
+// resolution, SetTimeStamp rounds to the nearest unit.
+func ParseCustomTime(id uuid.UUID, nBytes int, res time.Duration) time.Time {
+	return UUIDTimeToUnix(uuid.Time(ReadCustomTimeStamp(id, nBytes) * ticks(res)))
+}
+
 // NewTimeStampedUUID returns a UUID with 73bits of cryptographically
 // random data in its first 10 bytes, and 6 bytes of timestamp data
 // after that, the timestamp has a 10th of a millisecond precision and
 // covers a temporal range of 892 years before wrapping.  7 bits are
 // used to set values so as to remain rfc4122 compatible, comprising of
 // the variant and version information, variant future and version 6.
+// The time is that of DefaultClock, an error wrapping ErrInvalidClock
+// being returned should it be before MinValidTime.
 func NewTimeStampedUUID() (uuid.UUID, error) {
-	now, _, err := uuid.GetTime()
+	if Observer == nil {
+		return newTimeStampedUUID()
+	}
+	start := time.Now()
+	id, err := newTimeStampedUUID()
+	Observer(time.Since(start), err)
+	return id, err
+}
+
+// Observer, when not nil, is called by NewTimeStampedUUID after each
+// generation with the time taken and any error returned, so that an
+// application may record metrics.  It should be set once, before any
+// UUIDs are generated, and must be safe for concurrent use.
+var Observer func(dur time.Duration, err error)
+
+func newTimeStampedUUID() (uuid.UUID, error) {
+	now, err := defaultNow()
 	if err != nil {
 		return uuid.Nil, fmt.Errorf("NewTimeStampedUUID: %w", err)
 	}
-	return CustomTimeStampedUUID(rand.Reader, 6, now, time.Millisecond/10, true)
+	id, err := pooledTimeStampedUUID(now)
+	if err != nil {
+		return id, fmt.Errorf("NewTimeStampedUUID: %w", err)
+	}
+	return id, nil
 }
 
+// NewTimeStampedUUIDFrom returns a UUID in the same layout as
+// NewTimeStampedUUID, its random data being read from r.
+func NewTimeStampedUUIDFrom(r io.Reader) (uuid.UUID, error) {
+	now, _, err := uuid.GetTime()
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("NewTimeStampedUUIDFrom: %w", err)
+	}
+	var id uuid.UUID
+	if err := Fill(&id, r, now); err != nil {
+		return uuid.Nil, fmt.Errorf("NewTimeStampedUUIDFrom: %w", err)
+	}
+	return id, nil
+}
+
+// Fill writes a UUID in the layout of NewTimeStampedUUID, stamped with the
+// time t and its random data read from r, directly into dst.  It is the
+// lowest level of the generators and does not allocate unless it fails.
+func Fill(dst *uuid.UUID, r io.Reader, t uuid.Time) error {
+	const nBytes = 6
+	if _, err := io.ReadFull(r, dst[:len(dst)-nBytes]); err != nil {
+		return readError{err}
+	}
+	uint64ToBytes(dst[len(dst)-nBytes:], nBytes, toTicks(t, time.Millisecond/10)&(1<<(nBytes*8)-1))
+	setRFCBits(dst)
+	return nil
+}
+
+// NewRaw returns a UUID with 10 bytes of cryptographically random data
+// followed by 6 bytes of time stamp as does NewTimeStampedUUID, without
+// setting the version and variant bits, giving 80 bits of random data in
+// place of 73.  The result does not carry a valid uuid version or variant
+// and so will not validate as an rfc4122 uuid, nor as one of this
+// package's.
+func NewRaw() (uuid.UUID, error) {
+	now, _, err := uuid.GetTime()
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("NewRaw: %w", err)
+	}
+	id, err := CustomTimeStampedUUID(rand.Reader, 6, now, time.Millisecond/10, false)
+	if err != nil {
+		return id, fmt.Errorf("NewRaw: %w", err)
+	}
+	return id, nil
+}
+
+// NewTimeStampedUUIDAt returns a UUID in the same layout as
+// NewTimeStampedUUID stamped with the time t rather than the current time,
+// for the back filling of historical records.
+func NewTimeStampedUUIDAt(t time.Time) (uuid.UUID, error) {
+	id, err := CustomTimeStampedUUID(rand.Reader, 6, UnixToUUIDTime(t), time.Millisecond/10, true)
+	if err != nil {
+		return id, fmt.Errorf("NewTimeStampedUUIDAt: %w", err)
+	}
+	return id, nil
+}
+
+// NewQuantized returns a UUID in the same layout as NewTimeStampedUUID
+// stamped with the current time rounded down to a multiple of bucket, so
+// as not to reveal the precise time at which it was generated.  UUIDs from
+// the same bucket carry the same time stamp, they sort by time only to the
+// granularity of the bucket.
+func NewQuantized(bucket time.Duration) (uuid.UUID, error) {
+	id, err := NewTimeStampedUUIDAt(time.Now().Truncate(bucket))
+	if err != nil {
+		return id, fmt.Errorf("NewQuantized: %w", err)
+	}
+	return id, nil
+}
+
+// SetTimeStamp writes the time t, rounded to the given resolution, into
+// the last nBytes of the uuid.  Should the time stamp not fit into nBytes
+// only its least significant bytes are written, the time stamp wraps;
+// SetTimeStampStrict returns an error instead.
 func SetTimeStamp(id uuid.UUID, nBytes int, t uuid.Time, res time.Duration) (uuid.UUID, error) {
-	// Translate duration into parts per second, the time is already being
-	// returned from GetTime in 100th's of a nano second, dividing 1e8 by
-	// the resolution gives us the correct numerator when using this time
-	// format.
-	res = time.Second / 10 / res // Translate duration into parts per second.
 	if nBytes > len(id) {
-		return id, errors.New("to many bytes to format")
+		return id, fmt.Errorf("%w, %d exceeds %d", ErrTooManyBytes, nBytes, len(id))
+	}
+	if nBytes < 1 {
+		return id, fmt.Errorf("%w, %d given at least 1 is required", ErrTooFewBytes, nBytes)
 	}
 
-	// Write the first 6 bytes with the least significant 6 bytes of the
-	// current Time as measured in 100s of microseconds since 15 Oct 1582.
-	mask := uint64(1<<uint64(nBytes*8) - 1)
-	timeBytes := uint64(math.Round((float64(t) / float64(res)))) & mask
+	if nBytes == 6 {
+		putTimeStamp6(id[10:], toTicks(t, res))
+		return id, nil
+	}
+	return setTimeStamp(id, nBytes, t, res), nil
+}
+
+// setTimeStamp is the general path of SetTimeStamp for any valid nBytes.
+func setTimeStamp(id uuid.UUID, nBytes int, t uuid.Time, res time.Duration) uuid.UUID {
+	// Write the last nBytes with the least significant nBytes of the
+	// given Time as measured in units of res since 15 Oct 1582.
+	mask := timeStampMask(nBytes)
+	timeBytes := toTicks(t, res) & mask
+	uint64ToBytes(id[len(id)-nBytes:], nBytes, timeBytes)
+	return id
+}
+
+// putTimeStamp6 writes the least significant 6 bytes of v into b, big
+// endian, the fast path of SetTimeStamp for the default width.
+func putTimeStamp6(b []byte, v uint64) {
+	_ = b[5] // early bounds check to guarantee safety of writes below
+	b[0] = byte(v >> 40)
+	b[1] = byte(v >> 32)
+	b[2] = byte(v >> 24)
+	b[3] = byte(v >> 16)
+	b[4] = byte(v >> 8)
+	b[5] = byte(v)
+}
+
+// timeStampMask returns the mask of the bits held by a time stamp of
+// nBytes.  A shift of 64 or more is well defined in Go, yielding 0 so that
+// subtracting 1 would give the full mask, yet to be explicit 8 bytes and
+// above are special cased as all bits set.
+func timeStampMask(nBytes int) uint64 {
+	if nBytes >= maxTimeStampBytes {
+		return ^uint64(0)
+	}
+	return 1<<uint64(nBytes*8) - 1
+}
+
+// SetTimeStampStrict writes the time t as does SetTimeStamp, returning an
+// error rather than wrapping when the time stamp is too large to be held
+// in nBytes.
+func SetTimeStampStrict(id uuid.UUID, nBytes int, t uuid.Time, res time.Duration) (uuid.UUID, error) {
+	if nBytes > len(id) {
+		return id, fmt.Errorf("%w, %d exceeds %d", ErrTooManyBytes, nBytes, len(id))
+	}
+	if nBytes < 1 {
+		return id, fmt.Errorf("%w, %d given at least 1 is required", ErrTooFewBytes, nBytes)
+	}
+
+	mask := timeStampMask(nBytes)
+	timeBytes := toTicks(t, res)
+	if timeBytes > mask {
+		return id, fmt.Errorf("%w, %d exceeds the %d byte maximum of %d",
+			ErrTimestampRange, timeBytes, nBytes, mask)
+	}
 	uint64ToBytes(id[len(id)-nBytes:], nBytes, timeBytes)
 	return id, nil
 }
 
+// toTicks converts the uuid.Time t into units of res, rounding to the
+// nearest unit.
+func toTicks(t uuid.Time, res time.Duration) uint64 {
+	div := ticks(res)
+	return (uint64(t) + div/2) / div
+}
+
+// gregorianOffset is the number of 100 nano second intervals between the
+// start of the Gregorian calendar on 15 Oct 1582, the epoch of uuid.Time,
+// and the Unix epoch.
+const gregorianOffset = 122192928000000000
+
+// UnixToUUIDTime converts t into a uuid.Time, the number of 100 nano
+// second intervals since the start of the Gregorian calendar on 15 Oct
+// 1582, truncating it to 100 nano seconds.  Times before 1582 can not be
+// represented.
+func UnixToUUIDTime(t time.Time) uuid.Time {
+	return uuid.Time(t.Unix()*1e7 + int64(t.Nanosecond()/100) + gregorianOffset)
+}
+
+// UUIDTimeToUnix converts the uuid.Time ut into a time.Time in UTC.
+func UUIDTimeToUnix(ut uuid.Time) time.Time {
+	return time.Unix(ut.UnixTime()).UTC()
+}
+
+// ticks returns the number of 100 nano second intervals, the unit in which
+// uuid.Time is measured, that are contained within the given resolution.
+// Resolutions finer than 100 nano seconds can not be represented and are
+// treated as 100 nano seconds.
+func ticks(res time.Duration) uint64 {
+	div := uint64(res / 100)
+	if div == 0 {
+		return 1
+	}
+	return div
+}
+
+// maxTimeStampBytes is the largest time stamp that can be written, the
+// width of the uint64 in which it is computed, a width that leaves 8 bytes
+// of random data.  With rfc4122 set the time stamp may not exceed
+// maxRFCTimeStampBytes, beyond which it would overwrite the variant held in
+// byte 8.
+const (
+	maxTimeStampBytes    = 8
+	maxRFCTimeStampBytes = 7
+)
+
+// checkWidth returns an error if a trailing time stamp of nBytes either
+// leaves no room or would collide with the version and variant bits.
+func checkWidth(nBytes int, rfc4122 bool) error {
+	switch {
+	case nBytes < 1:
+		return fmt.Errorf("%w, %d given at least 1 is required", ErrTooFewBytes, nBytes)
+	case nBytes > maxTimeStampBytes:
+		return fmt.Errorf("%w, %d exceeds the maximum of %d",
+			ErrTooManyBytes, nBytes, maxTimeStampBytes)
+	case rfc4122 && nBytes > maxRFCTimeStampBytes:
+		return fmt.Errorf("%w, %d overwrites the rfc4122 variant, the maximum is %d",
+			ErrTooManyBytes, nBytes, maxRFCTimeStampBytes)
+	}
+	return nil
+}
+
 // CustomTimeStampedUUID generates a uuid.UUID with n bytes of time stamp set
-// to the given time resolution and the remaining bytes random data.
+// to the given time resolution and the remaining bytes random data.  nBytes
+// must be between 1 and 8, or between 1 and 7 when rfc4122 is set so as to
+// leave the variant and version bits intact.
 func CustomTimeStampedUUID(r io.Reader, nBytes int, t uuid.Time, res time.Duration, rfc4122 bool) (uuid.UUID, error) {
 	var id uuid.UUID
 	const fname = "CustomTimeStampedUUID"
@@ -98,6 +382,10 @@ func CustomTimeStampedUUID(r io.Reader, nBytes int, t uuid.Time, res time.Durati
 		return id, fmt.Errorf("%s: %w", fname, err)
 	}
 
+	if err = checkWidth(nBytes, rfc4122); err != nil {
+		return fail(err)
+	}
+
 	id, err = SetTimeStamp(id, nBytes, t, res)
 	if err != nil {
 		return fail(err)
@@ -106,39 +394,162 @@ func CustomTimeStampedUUID(r io.Reader, nBytes int, t uuid.Time, res time.Durati
 	// Fill the remaining bytes with values from the io.Reader.
 	_, err = io.ReadFull(r, id[:len(id)-nBytes])
 	if err != nil {
-		return fail(err)
+		return fail(readError{err})
 	}
 
 	if rfc4122 {
 		// In accordance with rfc4122 Set version to 6, an as yet unspecifed
 		// version.
-		id[6] = (id[6] & 0x0f) | 0x60 // Version 6
-		id[8] = (id[8] & 0x3f) | 0xe0 // Variant is 111, future
+		setRFCBits(&id)
 	}
 
 	return id, nil
 }
 
-// timeRange displays information about the time range available if a
-// specific time duration is set to be the length of time represented by
-// an integer for the specified word size.
-func timeRange(wordSize uint64, timeResolution time.Duration) {
+// RestampUUID replaces the time stamp of a TimeStampedUUID with the time
+// t, leaving its first 10 bytes of random data untouched, the version and
+// variant bits are reapplied.
+func RestampUUID(id uuid.UUID, t time.Time) (uuid.UUID, error) {
+	id, err := SetTimeStamp(id, 6, UnixToUUIDTime(t), time.Millisecond/10)
+	if err != nil {
+		return id, fmt.Errorf("RestampUUID: %w", err)
+	}
+	setRFCBits(&id)
+	return id, nil
+}
+
+// RestampAll replaces, in place, the time stamp of each of the
+// TimeStampedUUIDs ids with the time t, leaving their first 10 bytes, the
+// random data and the version and variant, untouched.  The time stamp is
+// computed once for the whole slice.
+func RestampAll(ids []uuid.UUID, t time.Time) {
+	var stamp [6]byte
+	uint64ToBytes(stamp[:], 6, toTicks(UnixToUUIDTime(t), time.Millisecond/10))
+	for i := range ids {
+		copy(ids[i][10:], stamp[:])
+	}
+}
+
+// NewLike returns a new TimeStampedUUID with fresh random data that
+// carries the same time stamp as other, so as to sort alongside it.
+func NewLike(other uuid.UUID) (uuid.UUID, error) {
+	var id uuid.UUID
+	if _, err := io.ReadFull(rand.Reader, id[:10]); err != nil {
+		return uuid.Nil, fmt.Errorf("NewLike: %w", readError{err})
+	}
+	copy(id[10:], other[10:])
+	setRFCBits(&id)
+	return id, nil
+}
+
+// Migrate converts a uuid of any version, typically a random version 4
+// uuid, into a TimeStampedUUID stamped with createdAt, keeping its first
+// 10 bytes as its random identity.  As the version and variant bits are
+// overwritten the result is no longer a version 4 uuid, so that any
+// downstream validation of the version must be updated to accept version
+// 6.
+func Migrate(id uuid.UUID, createdAt time.Time) uuid.UUID {
+	// RestampUUID can only fail for a time stamp wider than the uuid.
+	id, _ = RestampUUID(id, createdAt)
+	return id
+}
+
+// Span is a length of time expressed in years, days and seconds, a
+// time.Duration being too short to hold the ranges that a time stamp can
+// cover.
+type Span struct {
+	Years   int64
+	Days    int64
+	Seconds float64
+}
+
+// String returns the span formatted as years, days and seconds.
+func (s Span) String() string {
+	return fmt.Sprintf("%d years %d days %f seconds", s.Years, s.Days, s.Seconds)
+}
+
+// TimeRange returns the time range available if a specific time duration
+// is set to be the length of time represented by an integer for the
+// specified word size, expressed in bits.  A word size of 64 bits or more
+// is taken to hold 2^64-1 units, the most that a uint64 can count.  The
+// span is computed exactly, in nano seconds, for any resolution, the years
+// saturating should they exceed an int64; a resolution of zero or less
+// gives the zero Span.
+func TimeRange(wordSize uint64, timeResolution time.Duration) Span {
 	const avgYear = 365.24219
-	const secPerDay = 88400
+	const secPerDay = 86400
+
+	if timeResolution <= 0 {
+		return Span{}
+	}
+	units := ^uint64(0) // Total units available.
+	if wordSize < 64 {
+		units = 1 << wordSize
+	}
 
-	partsPerSecond := time.Second / timeResolution // Translate duration into parts per second.
-	normalised := 1 / float64(partsPerSecond)
-	units := int64(math.Pow(2, float64(wordSize))) // Total units available.
+	// The length of that time in nano seconds may well exceed a uint64.
+	ns := new(big.Int).Mul(new(big.Int).SetUint64(units), big.NewInt(int64(timeResolution)))
+	seconds, nsRmn := new(big.Int).QuoRem(ns, big.NewInt(1e9), new(big.Int))
+	days, secondsRmn := new(big.Int).QuoRem(seconds, big.NewInt(secPerDay), new(big.Int))
 
-	seconds := units / int64(partsPerSecond) // Length of that time in seconds.
-	unitsRmn := units % int64(partsPerSecond)
+	fdays, _ := new(big.Float).SetInt(days).Float64()
+	fyears := math.Floor(fdays / avgYear) // Length of that time in years.
+	years := int64(math.MaxInt64)
+	if fyears < math.MaxInt64 {
+		years = int64(fyears)
+	}
+	daysRmn := int64((fdays/avgYear - fyears) * avgYear)
 
-	days := seconds / secPerDay // Length of that time in days.
-	secondsRmn := seconds % secPerDay
+	return Span{
+		Years:   years,
+		Days:    daysRmn,
+		Seconds: float64(secondsRmn.Int64()) + float64(nsRmn.Int64())/1e9,
+	}
+}
 
-	years := int64(float64(days) / avgYear) //Length of that time in years.
-	daysRemainder := (float64(days) / avgYear) - float64(years)
-	daysRmn := int64(daysRemainder * avgYear)
+// MaxTime returns the latest time that can be held in a time stamp of
+// nBytes at the resolution res before it wraps, nBytes being limited
+// to between 1 and 8 bytes.
+func MaxTime(nBytes int, res time.Duration) time.Time {
+	if nBytes < 1 {
+		nBytes = 1
+	}
+	if nBytes > maxTimeStampBytes {
+		nBytes = maxTimeStampBytes
+	}
+	mask := timeStampMask(nBytes)
+
+	// The span in nano seconds may well exceed an int64.
+	ns := new(big.Int).Mul(new(big.Int).SetUint64(mask), new(big.Int).SetUint64(ticks(res)*100))
+	sec, nsec := new(big.Int).QuoRem(ns, big.NewInt(1e9), new(big.Int))
+	sec.Sub(sec, big.NewInt(gregorianOffset/1e7))
+	if !sec.IsInt64() {
+		sec.SetInt64(math.MaxInt64)
+	}
+	return time.Unix(sec.Int64(), nsec.Int64()).UTC()
+}
 
-	fmt.Printf("%d years %d days %f seconds\n", years, daysRmn, float64(secondsRmn)+(normalised*float64(unitsRmn)))
+// randomBits is the number of random bits in the layout of
+// NewTimeStampedUUID, 10 bytes less the 7 bits of version and variant.
+const randomBits = 10*8 - 7
+
+// CollisionProbability estimates the probability that, of idsPerTick
+// UUIDs generated by NewTimeStampedUUID within the same 10th of a
+// millisecond, any two are the same.  UUIDs from different ticks can not
+// collide, their time stamps differ, so only those within a tick compete
+// for the 73 bits of random data.  The birthday approximation
+// 1 - e^(-n(n-1)/2^74) is used, which assumes a uniformly random source.
+func CollisionProbability(idsPerTick int) float64 {
+	n := float64(idsPerTick)
+	if n < 2 {
+		return 0
+	}
+	return -math.Expm1(-n * (n - 1) / (2 * math.Ldexp(1, randomBits)))
+}
+
+// timeRange displays information about the time range available if a
+// specific time duration is set to be the length of time represented by
+// an integer for the specified word size.
+func timeRange(wordSize uint64, timeResolution time.Duration) {
+	fmt.Println(TimeRange(wordSize, timeResolution))
 }
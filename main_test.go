@@ -1,7 +1,12 @@
 package comb
 
 import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -54,3 +59,735 @@ func TestBytesToUint64(t *testing.T) {
 		t.Errorf("want %b got %b", j, i)
 	}
 }
+
+func TestBytesToUint64Widths(t *testing.T) {
+	b := []byte{0x01, 0x23, 0x45, 0x67, 0x89}
+	tests := []struct {
+		n    int
+		want uint64
+	}{
+		{1, 0x01},
+		{3, 0x012345},
+		{5, 0x0123456789},
+	}
+	for _, test := range tests {
+		if got := bytesToUint64(b[:test.n], test.n); got != test.want {
+			t.Errorf("%d bytes: want %#x got %#x", test.n, test.want, got)
+		}
+		out := make([]byte, test.n)
+		uint64ToBytes(out, test.n, test.want)
+		if !bytes.Equal(out, b[:test.n]) {
+			t.Errorf("%d bytes: want %x got %x", test.n, b[:test.n], out)
+		}
+	}
+}
+
+func TestSetTimeStampRoundTrip(t *testing.T) {
+	const want = uint64(0x0123456789ab)
+	// SetTimeStamp divides the uuid.Time by the resolution, a 10th of a
+	// millisecond being 1000 units of 100 nano seconds.
+	id, err := SetTimeStamp(uuid.Nil, 6, uuid.Time(want*1000), time.Millisecond/10)
+	if err != nil {
+		t.Error("did not expect an error:", err)
+	}
+	str := "00000000-0000-0000-0000-0123456789ab"
+	if id.String() != str {
+		t.Errorf("want %q got %q", str, id.String())
+	}
+	if got := ReadTimeStamp(id); got != want {
+		t.Errorf("want %#x got %#x", want, got)
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	now, _, err := uuid.GetTime()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	want := time.Unix(now.UnixTime())
+	tests := []struct {
+		nBytes int
+		res    time.Duration
+	}{
+		{6, time.Millisecond / 10},
+		{7, time.Microsecond},
+		{6, time.Millisecond},
+		{5, time.Second},
+	}
+	for _, test := range tests {
+		id, err := CustomTimeStampedUUID(rand.Reader, test.nBytes, now, test.res, true)
+		if err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		got := ParseCustomTime(id, test.nBytes, test.res)
+		if d := got.Sub(want); d > test.res || d < -test.res {
+			t.Errorf("%d bytes at %v: want %v got %v", test.nBytes, test.res, want, got)
+		}
+	}
+
+	id, err := NewTimeStampedUUID()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if d := time.Since(ParseTime(id)); d > time.Second || d < -time.Second {
+		t.Errorf("want a time close to now got %v", ParseTime(id))
+	}
+}
+
+func TestTimeRange(t *testing.T) {
+	// 2^48 units of a 10th of a millisecond, the 892 years of the package
+	// documentation rounded up.
+	span := TimeRange(48, time.Millisecond/10)
+	if span.Years != 891 || span.Days != 350 {
+		t.Errorf("want 891 years 350 days got %v", span)
+	}
+	years := float64(span.Years) + float64(span.Days)/365.24219
+	if math.Round(years) != 892 {
+		t.Errorf("want 892 years got %f", years)
+	}
+}
+
+func TestNewTimeStampedUUIDFrom(t *testing.T) {
+	random := []byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef, 0x01, 0x23}
+	id, err := NewTimeStampedUUIDFrom(bytes.NewReader(random))
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	// Bytes 6 and 8 carry the version and variant.
+	want := []byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0x6d, 0xef, 0xe1, 0x23}
+	if !bytes.Equal(id[:10], want) {
+		t.Errorf("want %x got %x", want, id[:10])
+	}
+
+	_, err = NewTimeStampedUUIDFrom(bytes.NewReader(random[:5]))
+	if err == nil {
+		t.Error("expected an error on a short read")
+	}
+}
+
+func TestCustomTimeStampedUUIDWidth(t *testing.T) {
+	tests := []struct {
+		nBytes  int
+		rfc4122 bool
+		isErr   bool
+	}{
+		{-1, false, true},
+		{0, false, true},
+		{0, true, true},
+		{1, true, false},
+		{6, true, false},
+		{7, true, false},
+		{8, true, true},
+		{8, false, false},
+		{9, false, true},
+		{16, false, true},
+	}
+	for _, test := range tests {
+		_, err := CustomTimeStampedUUID(rand.Reader, test.nBytes, 0, time.Millisecond, test.rfc4122)
+		if test.isErr && err == nil {
+			t.Errorf("%d bytes rfc4122 %t: expected an error", test.nBytes, test.rfc4122)
+		}
+		if !test.isErr && err != nil {
+			t.Errorf("%d bytes rfc4122 %t: did not expect an error: %v", test.nBytes, test.rfc4122, err)
+		}
+	}
+}
+
+func TestRestampUUID(t *testing.T) {
+	id, err := NewTimeStampedUUID()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	when := time.Date(2001, 2, 3, 4, 5, 6, 700000000, time.UTC)
+	got, err := RestampUUID(id, when)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if !bytes.Equal(got[:10], id[:10]) {
+		t.Errorf("want random prefix %x got %x", id[:10], got[:10])
+	}
+	if err := Validate(got); err != nil {
+		t.Error("did not expect an error:", err)
+	}
+	if ReadTimeStamp(got) == ReadTimeStamp(id) {
+		t.Error("expected the time stamp to change")
+	}
+	if p := ParseTime(got); !p.Equal(when) {
+		t.Errorf("want %v got %v", when, p)
+	}
+}
+
+func TestSetTimeStampStrict(t *testing.T) {
+	const res = time.Millisecond / 10
+	const max = 1<<48 - 1
+
+	last := uuid.Time(max * 1000)
+	id, err := SetTimeStampStrict(uuid.Nil, 6, last, res)
+	if err != nil {
+		t.Error("did not expect an error:", err)
+	}
+	if got := ReadTimeStamp(id); got != max {
+		t.Errorf("want %d got %d", uint64(max), got)
+	}
+
+	// One tick beyond the range wraps to zero unless strict.
+	beyond := last + 1000
+	id, err = SetTimeStamp(uuid.Nil, 6, beyond, res)
+	if err != nil {
+		t.Error("did not expect an error:", err)
+	}
+	if got := ReadTimeStamp(id); got != 0 {
+		t.Errorf("want 0 got %d", got)
+	}
+	if _, err = SetTimeStampStrict(uuid.Nil, 6, beyond, res); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestSplit(t *testing.T) {
+	id := uuid.MustParse("01234567-89ab-6def-e123-456789abcdef")
+	random, ts := Split(id)
+	want := []byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0x6d, 0xef, 0xe1, 0x23}
+	if !bytes.Equal(random, want) {
+		t.Errorf("want %x got %x", want, random)
+	}
+	if ts != 0x456789abcdef {
+		t.Errorf("want %#x got %#x", 0x456789abcdef, ts)
+	}
+	random[0] = 0xff
+	if id[0] != 0x01 {
+		t.Error("expected the random bytes to be a copy")
+	}
+
+	tests := []struct {
+		nBytes int
+		random []byte
+		ts     uint64
+	}{
+		{4, id[:12], 0x89abcdef},
+		{0, id[:], 0},
+		{-1, id[:], 0},
+		{8, id[:8], 0xe123456789abcdef},
+		{9, id[:8], 0xe123456789abcdef},
+		{16, id[:8], 0xe123456789abcdef},
+		{17, id[:8], 0xe123456789abcdef},
+	}
+	for _, test := range tests {
+		random, ts := SplitCustom(id, test.nBytes)
+		if !bytes.Equal(random, test.random) || ts != test.ts {
+			t.Errorf("%d bytes: want %x %#x got %x %#x", test.nBytes, test.random, test.ts, random, ts)
+		}
+	}
+}
+
+func TestNewTimeStampedUUIDAt(t *testing.T) {
+	const res = time.Millisecond / 10
+	for _, want := range []time.Time{
+		time.Date(1999, 12, 31, 23, 59, 59, 999999999, time.UTC),
+		time.Date(2024, 6, 1, 12, 0, 0, 123456789, time.UTC),
+		time.Now(),
+	} {
+		id, err := NewTimeStampedUUIDAt(want)
+		if err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		if err := Validate(id); err != nil {
+			t.Error("did not expect an error:", err)
+		}
+		got := ParseTime(id)
+		if d := got.Sub(want); d > res || d < -res {
+			t.Errorf("want %v got %v", want, got)
+		}
+	}
+}
+
+func FuzzReadCustomTimeStamp(f *testing.F) {
+	for _, n := range []int{-1, 0, 1, 6, 8, 9, 16, 17, 1 << 30} {
+		f.Add([]byte("0123456789abcdef"), n)
+	}
+	f.Fuzz(func(t *testing.T, b []byte, nBytes int) {
+		var id uuid.UUID
+		copy(id[:], b)
+		ts := ReadCustomTimeStamp(id, nBytes)
+		if nBytes < 1 && ts != 0 {
+			t.Errorf("%d bytes: want 0 got %d", nBytes, ts)
+		}
+		if nBytes > 8 && ts != ReadCustomTimeStamp(id, 8) {
+			t.Errorf("%d bytes: want %d got %d", nBytes, ReadCustomTimeStamp(id, 8), ts)
+		}
+	})
+}
+
+func TestEpochConversion(t *testing.T) {
+	tests := []struct {
+		name string
+		t    time.Time
+		ut   uuid.Time
+	}{
+		{"gregorian", time.Date(1582, 10, 15, 0, 0, 0, 0, time.UTC), 0},
+		{"unix", time.Unix(0, 0).UTC(), 122192928000000000},
+		{"2024", time.Date(2024, 6, 1, 12, 0, 0, 100, time.UTC), 139365360000000001},
+	}
+	for _, test := range tests {
+		if ut := UnixToUUIDTime(test.t); ut != test.ut {
+			t.Errorf("%s: want %d got %d", test.name, test.ut, ut)
+		}
+		if got := UUIDTimeToUnix(test.ut); !got.Equal(test.t) {
+			t.Errorf("%s: want %v got %v", test.name, test.t, got)
+		}
+	}
+
+	// Time finer than 100 nano seconds is truncated.
+	when := time.Date(2024, 6, 1, 12, 0, 0, 199, time.UTC)
+	if ut := UnixToUUIDTime(when); ut != 139365360000000001 {
+		t.Errorf("want %d got %d", 139365360000000001, ut)
+	}
+}
+
+func TestMaxTime(t *testing.T) {
+	const res = time.Millisecond / 10
+	max := MaxTime(6, res)
+	// 1582 plus 892 years.
+	if max.Year() != 2474 {
+		t.Errorf("want 2474 got %v", max)
+	}
+	if _, err := SetTimeStampStrict(uuid.Nil, 6, UnixToUUIDTime(max), res); err != nil {
+		t.Error("did not expect an error:", err)
+	}
+	if _, err := SetTimeStampStrict(uuid.Nil, 6, UnixToUUIDTime(max.Add(res)), res); err == nil {
+		t.Error("expected an error")
+	}
+
+	gregorian := time.Date(1582, 10, 15, 0, 0, 0, 0, time.UTC)
+	if got, want := MaxTime(1, time.Second), gregorian.Add(255*time.Second); !got.Equal(want) {
+		t.Errorf("want %v got %v", want, got)
+	}
+	if !MaxTime(8, 100).After(MaxTime(7, 100)) {
+		t.Error("want the 8 byte maximum after the 7 byte maximum")
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	v4 := uuid.MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	createdAt := time.Date(2019, 3, 14, 15, 9, 26, 535900000, time.UTC)
+	id := Migrate(v4, createdAt)
+	want := "f47ac10b-58cc-6372-e567-"
+	if id.String()[:24] != want {
+		t.Errorf("want prefix %q got %q", want, id.String())
+	}
+	if err := Validate(id); err != nil {
+		t.Error("did not expect an error:", err)
+	}
+	if got := ParseTime(id); !got.Equal(createdAt) {
+		t.Errorf("want %v got %v", createdAt, got)
+	}
+}
+
+func TestNewLike(t *testing.T) {
+	other, err := NewTimeStampedUUIDAt(time.Date(2020, 2, 2, 2, 2, 2, 0, time.UTC))
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	id, err := NewLike(other)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if bytes.Equal(id[:10], other[:10]) {
+		t.Errorf("want a different random prefix got %x", id[:10])
+	}
+	if !ParseTime(id).Equal(ParseTime(other)) {
+		t.Errorf("want %v got %v", ParseTime(other), ParseTime(id))
+	}
+	if err := Validate(id); err != nil {
+		t.Error("did not expect an error:", err)
+	}
+}
+
+func TestNewRaw(t *testing.T) {
+	// Over 64 uuids the chance of every version and variant matching by
+	// chance alone is negligible.
+	version, variant := true, true
+	for i := 0; i < 64; i++ {
+		id, err := NewRaw()
+		if err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		version = version && id[6]&0xf0 == 0x60
+		variant = variant && id[8]&0xe0 == 0xe0
+		if d := time.Since(ParseTime(id)); d > time.Second || d < -time.Second {
+			t.Errorf("want a time close to now got %v", ParseTime(id))
+		}
+	}
+	if version || variant {
+		t.Errorf("want unforced bits, version forced %t variant forced %t", version, variant)
+	}
+
+	// Without rfc4122 every byte before the time stamp is the reader's.
+	random := bytes.Repeat([]byte{0x0a}, 10)
+	id, err := CustomTimeStampedUUID(bytes.NewReader(random), 6, 0, time.Millisecond/10, false)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if !bytes.Equal(id[:10], random) {
+		t.Errorf("want %x got %x", random, id[:10])
+	}
+}
+
+func TestCollisionProbability(t *testing.T) {
+	tests := []struct {
+		n    int
+		want float64
+	}{
+		{0, 0},
+		{1, 0},
+		// A single pair, 1 in 2^73.
+		{2, 1.0587911840678754e-22},
+		// 1e9(1e9-1)/2 pairs, x = 4.999999995e17 / 2^73, 1 - e^-x.
+		{1e9, 5.2938157876e-5},
+		// sqrt(2 ln 2 2^73), half way.
+		{114425434466, 0.5},
+	}
+	for _, test := range tests {
+		got := CollisionProbability(test.n)
+		if test.want == 0 {
+			if got != 0 {
+				t.Errorf("%d: want 0 got %g", test.n, got)
+			}
+			continue
+		}
+		if math.Abs(got-test.want)/test.want > 1e-6 {
+			t.Errorf("%d: want %g got %g", test.n, test.want, got)
+		}
+	}
+}
+
+func TestNewQuantized(t *testing.T) {
+	const bucket = time.Minute
+	before := time.Now()
+	a, err := NewQuantized(bucket)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	b, err := NewQuantized(bucket)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	after := time.Now()
+	if before.Truncate(bucket) != after.Truncate(bucket) {
+		t.Skip("straddled a bucket boundary")
+	}
+
+	if !EqualTime(a, b) {
+		t.Errorf("want equal time stamps got %v and %v", ParseTime(a), ParseTime(b))
+	}
+	if got, want := ParseTime(a), before.Truncate(bucket); !got.Equal(want) {
+		t.Errorf("want %v got %v", want, got)
+	}
+}
+
+func TestFill(t *testing.T) {
+	random := []byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef, 0x01, 0x23}
+	now, _, err := uuid.GetTime()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	want, err := CustomTimeStampedUUID(bytes.NewReader(random), 6, now, time.Millisecond/10, true)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	var got uuid.UUID
+	if err := Fill(&got, bytes.NewReader(random), now); err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if got != want {
+		t.Errorf("want %v got %v", want, got)
+	}
+	if err := Fill(&got, bytes.NewReader(random[:9]), now); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestFillAllocs(t *testing.T) {
+	var id uuid.UUID
+	n := testing.AllocsPerRun(1000, func() {
+		if err := Fill(&id, rand.Reader, 0); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if n != 0 {
+		t.Errorf("want 0 allocations got %v", n)
+	}
+}
+
+func BenchmarkFill(b *testing.B) {
+	var id uuid.UUID
+	now, _, _ := uuid.GetTime()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := Fill(&id, rand.Reader, now); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestSetTimeStampWideWidths(t *testing.T) {
+	// A resolution of 100 nano seconds stores the uuid.Time unchanged.
+	tests := []struct {
+		nBytes int
+		v      uint64
+	}{
+		{7, 0x00fedcba98765432},
+		{7, 1<<56 - 1},
+		{8, 0x0fedcba987654321},
+		{8, 1<<60 - 1},
+		{8, 1 << 63},
+		{8, ^uint64(0)},
+	}
+	for _, test := range tests {
+		id, err := SetTimeStampStrict(uuid.Nil, test.nBytes, uuid.Time(test.v), 100)
+		if err != nil {
+			t.Errorf("%d bytes: did not expect an error: %v", test.nBytes, err)
+		}
+		if got := ReadCustomTimeStamp(id, test.nBytes); got != test.v {
+			t.Errorf("%d bytes: want %#x got %#x", test.nBytes, test.v, got)
+		}
+	}
+
+	if m := timeStampMask(7); m != 1<<56-1 {
+		t.Errorf("want %#x got %#x", uint64(1<<56-1), m)
+	}
+	if m := timeStampMask(8); m != ^uint64(0) {
+		t.Errorf("want %#x got %#x", ^uint64(0), m)
+	}
+
+	// One past the 7 byte maximum wraps, or errors when strict.
+	id, err := SetTimeStamp(uuid.Nil, 7, uuid.Time(1<<56), 100)
+	if err != nil || ReadCustomTimeStamp(id, 7) != 0 {
+		t.Errorf("want a wrapped time stamp of 0 got %#x %v", ReadCustomTimeStamp(id, 7), err)
+	}
+	if _, err := SetTimeStampStrict(uuid.Nil, 7, uuid.Time(1<<56), 100); err == nil {
+		t.Error("expected an error")
+	}
+	if _, err := SetTimeStamp(uuid.Nil, 0, 0, 100); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestObserver(t *testing.T) {
+	var calls int
+	Observer = func(dur time.Duration, err error) {
+		calls++
+		if dur < 0 {
+			t.Errorf("want a positive duration got %v", dur)
+		}
+		if err != nil {
+			t.Error("did not expect an error:", err)
+		}
+	}
+	defer func() { Observer = nil }()
+
+	for i := 0; i < 5; i++ {
+		if _, err := NewTimeStampedUUID(); err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+	}
+	if calls != 5 {
+		t.Errorf("want 5 calls got %d", calls)
+	}
+}
+
+func TestTimeRangeWide(t *testing.T) {
+	// Computed independently with exact integer arithmetic, using a year of
+	// 365.24219 days; 2^48 * 100µs is a little under 892 years.
+	tests := []struct {
+		wordSize uint64
+		res      time.Duration
+		want     Span
+	}{
+		{48, time.Millisecond / 10, Span{891, 350, 19271.0656}},
+		{56, 100, Span{228, 123, 85803.7927936}},
+		{56, time.Microsecond, Span{2283, 151, 80437.927936}},
+		{56, time.Millisecond / 10, Span{228341, 226, 8592.7936}},
+		// At 64 bits the count of units saturates at 2^64-1.
+		{64, 1, Span{584, 201, 84873.709551615}},
+		{64, time.Second, Span{584554545395, 76, 25215}},
+	}
+	for _, test := range tests {
+		got := TimeRange(test.wordSize, test.res)
+		if got.Years != test.want.Years || got.Days != test.want.Days ||
+			math.Abs(got.Seconds-test.want.Seconds) > 1e-6 {
+			t.Errorf("%d bits at %v: want %v got %v", test.wordSize, test.res, test.want, got)
+		}
+	}
+}
+
+func TestFraction(t *testing.T) {
+	when := time.Date(2024, 6, 1, 12, 0, 7, 123400000, time.UTC)
+	id, err := NewTimeStampedUUIDAt(when)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if got, want := Fraction(id), 123400*time.Microsecond; got != want {
+		t.Errorf("want %v got %v", want, got)
+	}
+	// Finer than the resolution is rounded away.
+	id, _ = NewTimeStampedUUIDAt(when.Add(30 * time.Microsecond))
+	if got, want := Fraction(id), 123400*time.Microsecond; got != want {
+		t.Errorf("want %v got %v", want, got)
+	}
+	if got := Fraction(uuid.Nil); got != 0 {
+		t.Errorf("want 0 got %v", got)
+	}
+}
+
+func TestReadTimeStampAt(t *testing.T) {
+	id := uuid.MustParse("00000000-0000-6000-e0c0-ffee01020300")
+	tests := []struct {
+		start, nBytes int
+		want          uint64
+	}{
+		{10, 5, 0xffee010203}, // Short of the trailing byte.
+		{11, 4, 0xee010203},
+		{9, 3, 0xc0ffee},
+		{10, 6, ReadTimeStamp(id)},
+		{8, 8, 0xe0c0ffee01020300},
+	}
+	for _, test := range tests {
+		got, err := ReadTimeStampAt(id, test.start, test.nBytes)
+		if err != nil {
+			t.Errorf("%d at %d: did not expect an error: %v", test.nBytes, test.start, err)
+		}
+		if got != test.want {
+			t.Errorf("%d at %d: want %#x got %#x", test.nBytes, test.start, test.want, got)
+		}
+	}
+
+	// A value written with no trailing padding at an offset reads back.
+	var padded uuid.UUID
+	uint64ToBytes(padded[9:15], 6, 0x123456789abc)
+	if got, err := ReadTimeStampAt(padded, 9, 6); err != nil || got != 0x123456789abc {
+		t.Errorf("want %#x got %#x %v", 0x123456789abc, got, err)
+	}
+
+	bad := []struct {
+		start, nBytes int
+		err           error
+	}{
+		{10, 0, ErrTooFewBytes},
+		{0, 9, ErrTooManyBytes},
+		{-1, 4, ErrTooManyBytes},
+		{13, 4, ErrTooManyBytes},
+		{16, 1, ErrTooManyBytes},
+	}
+	for _, test := range bad {
+		if _, err := ReadTimeStampAt(id, test.start, test.nBytes); !errors.Is(err, test.err) {
+			t.Errorf("%d at %d: want %q got %v", test.nBytes, test.start, test.err, err)
+		}
+	}
+}
+
+func TestRestampAll(t *testing.T) {
+	ids, err := NewBatch(20)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	for i := range ids[10:] {
+		ids[10+i], _ = NewTimeStampedUUIDAt(time.Unix(int64(i), 0))
+	}
+	orig := append([]uuid.UUID(nil), ids...)
+
+	when := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	RestampAll(ids, when)
+	for i, id := range ids {
+		if !EqualIgnoringTime(id, orig[i]) {
+			t.Errorf("%d: want the random data of %v got %v", i, orig[i], id)
+		}
+		if got := ParseTime(id); !got.Equal(when) {
+			t.Errorf("%d: want %v got %v", i, when, got)
+		}
+		if want, _ := RestampUUID(orig[i], when); id != want {
+			t.Errorf("%d: want %v got %v", i, want, id)
+		}
+	}
+	RestampAll(nil, when)
+}
+
+func TestSetTimeStampFastPath(t *testing.T) {
+	values := []uuid.Time{0, 1, 0x0123456789abcdef, 1<<48 - 1, 1 << 48, uuid.Time(^uint64(0) >> 1)}
+	now, _, _ := uuid.GetTime()
+	values = append(values, now)
+	for _, res := range []time.Duration{100, time.Microsecond, time.Millisecond / 10, time.Second} {
+		for _, v := range values {
+			id := uuid.New()
+			fast, err := SetTimeStamp(id, 6, v, res)
+			if err != nil {
+				t.Fatal("did not expect an error:", err)
+			}
+			if general := setTimeStamp(id, 6, v, res); fast != general {
+				t.Errorf("%#x at %v: want %v got %v", uint64(v), res, general, fast)
+			}
+		}
+	}
+}
+
+func BenchmarkSetTimeStamp6(b *testing.B) {
+	now, _, _ := uuid.GetTime()
+	var id uuid.UUID
+	for i := 0; i < b.N; i++ {
+		id, _ = SetTimeStamp(id, 6, now, time.Millisecond/10)
+	}
+}
+
+func BenchmarkSetTimeStampGeneral(b *testing.B) {
+	now, _, _ := uuid.GetTime()
+	var id uuid.UUID
+	for i := 0; i < b.N; i++ {
+		id = setTimeStamp(id, 6, now, time.Millisecond/10)
+	}
+}
+
+func TestUnixMilli(t *testing.T) {
+	tests := []struct {
+		when time.Time
+		want int64
+	}{
+		{time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC), 1717243200000},
+		{time.Date(2024, 6, 1, 12, 0, 0, 123400000, time.UTC), 1717243200123},
+		{time.Date(2024, 6, 1, 12, 0, 0, 999900000, time.UTC), 1717243200999},
+		{time.Unix(0, 0), 0},
+	}
+	for _, test := range tests {
+		id, err := NewTimeStampedUUIDAt(test.when)
+		if err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		if got := UnixMilli(id); got != test.want {
+			t.Errorf("%v: want %d got %d", test.when, test.want, got)
+		}
+	}
+}
+
+func TestTimeRangeCoarse(t *testing.T) {
+	tests := []struct {
+		wordSize uint64
+		res      time.Duration
+		want     Span
+	}{
+		// 2^32 * 2s is 8589934592s, 99420 days and 46592s.
+		{32, 2 * time.Second, Span{Years: 272, Days: 74, Seconds: 46592}},
+		// 2^48 hours is 11728124029610 days and 16 hours.
+		{48, time.Hour, Span{Years: 32110540213, Days: 130, Seconds: 57600}},
+		{8, time.Second, Span{Seconds: 256}},
+		{0, 0, Span{}},
+		{48, 0, Span{}},
+		{48, -time.Second, Span{}},
+	}
+	for _, test := range tests {
+		if got := TimeRange(test.wordSize, test.res); got != test.want {
+			t.Errorf("%d bits at %v: want %v got %v", test.wordSize, test.res, test.want, got)
+		}
+	}
+	if span := TimeRange(64, math.MaxInt64); span.Years != math.MaxInt64 {
+		t.Errorf("want saturated years got %v", span)
+	}
+}
@@ -0,0 +1,117 @@
+package comb
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Monotonic generates time stamped UUIDs, in the same layout as
+// NewTimeStampedUUID, whose embedded time stamps are strictly increasing
+// within the process.  When the clock has not advanced past the last tick
+// that was emitted the time stamp is incremented by one tick instead, so
+// that when UUIDs are requested faster than one per 10th of a millisecond
+// the stamps run ahead of the clock, catching up once the rate drops.
+//
+// When the time stamp reaches the largest value that 6 bytes can hold it
+// can not be incremented further without wrapping, at which point New
+// returns an error rather than emit a UUID that would sort before its
+// predecessors.
+//
+// A Monotonic is safe for concurrent use.
+type Monotonic struct {
+	mu   sync.Mutex
+	r    io.Reader
+	last uint64
+}
+
+// NewMonotonic returns a Monotonic generator that draws its random data
+// from crypto/rand.
+func NewMonotonic() *Monotonic {
+	return &Monotonic{r: rand.Reader}
+}
+
+// New returns a time stamped UUID whose time stamp is greater than that of
+// any UUID previously returned by the generator.
+func (m *Monotonic) New() (uuid.UUID, error) {
+	const fname = "Monotonic.New"
+	const nBytes = 6
+	const res = time.Millisecond / 10
+	now, _, err := uuid.GetTime()
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", fname, err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	mask := timeStampMask(nBytes)
+	tick := toTicks(now, res) & mask
+	if tick <= m.last {
+		if m.last == mask {
+			return uuid.Nil, fmt.Errorf("%s: %w, can not increment without wrapping",
+				fname, ErrTimestampRange)
+		}
+		tick = m.last + 1
+	}
+
+	id, err := CustomTimeStampedUUID(m.r, nBytes, uuid.Time(tick*ticks(res)), res, true)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", fname, err)
+	}
+	m.last = tick
+	return id, nil
+}
+
+// maxShards is the number of shards that fit into the leading byte of a
+// UUID.
+const maxShards = 256
+
+// MonotonicSharded spreads generation across a number of Monotonic
+// generators, each with its own lock, so that many goroutines do not
+// contend for a single mutex.  Each shard writes its index into the first
+// byte of its UUIDs, the time stamps of a shard being strictly increasing
+// and UUIDs of different shards never being equal, though UUIDs from
+// different shards are not ordered among themselves.  The shard index
+// costs 8 of the 73 bits of random data.
+//
+// A MonotonicSharded is safe for concurrent use.
+type MonotonicSharded struct {
+	next   uint32
+	shards []*Monotonic
+}
+
+// NewMonotonicSharded returns a MonotonicSharded of the given number of
+// shards, limited to between 1 and 256, that draws its random data from
+// crypto/rand.
+func NewMonotonicSharded(shards int) *MonotonicSharded {
+	if shards < 1 {
+		shards = 1
+	}
+	if shards > maxShards {
+		shards = maxShards
+	}
+	m := &MonotonicSharded{shards: make([]*Monotonic, shards)}
+	for i := range m.shards {
+		m.shards[i] = NewMonotonic()
+	}
+	return m
+}
+
+// New returns a time stamped UUID from the next shard in turn, whose time
+// stamp is greater than that of any UUID previously returned by the shard,
+// the index of the shard being in its first byte.
+func (m *MonotonicSharded) New() (uuid.UUID, error) {
+	i := int(atomic.AddUint32(&m.next, 1)-1) % len(m.shards)
+	id, err := m.shards[i].New()
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("MonotonicSharded.New: %w", err)
+	}
+	id[0] = byte(i)
+	return id, nil
+}
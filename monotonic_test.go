@@ -0,0 +1,134 @@
+package comb
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestMonotonic(t *testing.T) {
+	m := NewMonotonic()
+	var last uint64
+	for i := 0; i < 1000; i++ {
+		id, err := m.New()
+		if err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		ts := ReadTimeStamp(id)
+		if ts <= last {
+			t.Fatalf("%d: want a time stamp greater than %d got %d", i, last, ts)
+		}
+		last = ts
+	}
+}
+
+func TestMonotonicConcurrent(t *testing.T) {
+	const workers, n = 8, 500
+	m := NewMonotonic()
+	var mu sync.Mutex
+	seen := make(map[uint64]bool)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				id, err := m.New()
+				if err != nil {
+					t.Error("did not expect an error:", err)
+					return
+				}
+				mu.Lock()
+				seen[ReadTimeStamp(id)] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if len(seen) != workers*n {
+		t.Errorf("want %d distinct time stamps got %d", workers*n, len(seen))
+	}
+}
+
+func TestMonotonicWrap(t *testing.T) {
+	const mask = 1<<48 - 1
+	m := NewMonotonic()
+
+	// One tick short of the end of the range the next UUID takes the last
+	// available stamp.
+	m.last = mask - 1
+	id, err := m.New()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if ts := ReadTimeStamp(id); ts != mask {
+		t.Errorf("want %d got %d", uint64(mask), ts)
+	}
+
+	// Once exhausted the generator refuses to wrap.
+	id, err = m.New()
+	if err == nil {
+		t.Error("expected an error")
+	}
+	if id != uuid.Nil {
+		t.Errorf("want %v got %v", uuid.Nil, id)
+	}
+}
+
+func TestMonotonicSharded(t *testing.T) {
+	const shards = 4
+	m := NewMonotonicSharded(shards)
+	last := make(map[byte]uint64)
+	for i := 0; i < 1000; i++ {
+		id, err := m.New()
+		if err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		if err := Validate(id); err != nil {
+			t.Error("did not expect an error:", err)
+		}
+		shard := id[0]
+		if shard >= shards {
+			t.Fatalf("want a shard below %d got %d", shards, shard)
+		}
+		ts := ReadTimeStamp(id)
+		if ts <= last[shard] {
+			t.Fatalf("%d: shard %d: want a time stamp greater than %d got %d", i, shard, last[shard], ts)
+		}
+		last[shard] = ts
+	}
+	if len(last) != shards {
+		t.Errorf("want %d shards used got %d", shards, len(last))
+	}
+
+	if n := len(NewMonotonicSharded(0).shards); n != 1 {
+		t.Errorf("want 1 shard got %d", n)
+	}
+	if n := len(NewMonotonicSharded(1000).shards); n != maxShards {
+		t.Errorf("want %d shards got %d", maxShards, n)
+	}
+}
+
+// Run with -cpu=16 to compare the generators under contention.
+func BenchmarkMonotonic(b *testing.B) {
+	m := NewMonotonic()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := m.New(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkMonotonicSharded(b *testing.B) {
+	m := NewMonotonicSharded(16)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := m.New(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,24 @@
+package comb
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NewFromName returns a UUID in the same layout as NewTimeStampedUUID,
+// stamped with the time t, whose leading bytes are the SHA-1 hash of the
+// namespace followed by the name, as for a version 5 UUID.  The same
+// inputs always yield the same UUID, entropy is traded for determinism, a
+// UUID from NewFromName is only as hard to guess as its name.
+func NewFromName(namespace uuid.UUID, name string, t time.Time) uuid.UUID {
+	h := sha1.New()
+	h.Write(namespace[:])
+	h.Write([]byte(name))
+	// The hash is longer than the 10 bytes read and the width is valid,
+	// so there is no error to return.
+	id, _ := CustomTimeStampedUUID(bytes.NewReader(h.Sum(nil)), 6, UnixToUUIDTime(t), time.Millisecond/10, true)
+	return id
+}
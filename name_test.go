@@ -0,0 +1,32 @@
+package comb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewFromName(t *testing.T) {
+	when := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	a := NewFromName(uuid.NameSpaceURL, "https://example.com", when)
+	b := NewFromName(uuid.NameSpaceURL, "https://example.com", when)
+	if a != b {
+		t.Errorf("want %v got %v", a, b)
+	}
+	if err := Validate(a); err != nil {
+		t.Error("did not expect an error:", err)
+	}
+	if got := ParseTime(a); !got.Equal(when) {
+		t.Errorf("want %v got %v", when, got)
+	}
+	if c := NewFromName(uuid.NameSpaceURL, "https://example.org", when); c == a {
+		t.Errorf("want a different uuid for a different name got %v", c)
+	}
+	if c := NewFromName(uuid.NameSpaceDNS, "https://example.com", when); c == a {
+		t.Errorf("want a different uuid for a different namespace got %v", c)
+	}
+	if c := NewFromName(uuid.NameSpaceURL, "https://example.com", when.Add(time.Second)); !EqualIgnoringTime(a, c) {
+		t.Errorf("want the same leading bytes for a different time got %v and %v", a, c)
+	}
+}
@@ -0,0 +1,31 @@
+package comb
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewNanoResolution(t *testing.T) {
+	before := time.Now().Truncate(100 * time.Nanosecond)
+	a, err := NewNanoResolution()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	b, err := NewNanoResolution()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if a.Version() != 6 {
+		t.Errorf("want version 6 got %d", a.Version())
+	}
+	if EqualTime(a, b) {
+		t.Errorf("want different time stamps got %v and %v", a, b)
+	}
+	ta, tb := ReadNanoTime(a), ReadNanoTime(b)
+	if !tb.After(ta) {
+		t.Errorf("want %v after %v", tb, ta)
+	}
+	if ta.Before(before) || time.Since(ta) > time.Second {
+		t.Errorf("want a time close to now got %v", ta)
+	}
+}
@@ -0,0 +1,198 @@
+package comb
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// NullUUID mimics the behaviour of the sql.Null* types
+type NullUUID struct {
+	uuid.UUID
+	Valid bool
+}
+
+// NewNullUUID returns a valid NullUUID holding id.
+func NewNullUUID(id uuid.UUID) NullUUID {
+	return NullUUID{UUID: id, Valid: true}
+}
+
+// ValueOrNil returns the uuid when the NullUUID is valid and nil when it
+// is not.
+func (n NullUUID) ValueOrNil() any {
+	if !n.Valid {
+		return nil
+	}
+	return n.UUID
+}
+
+// Scan implements the sql.Scanner interface. A nil src sets Valid to
+// false, string and []byte values are parsed as a uuid, a 16 byte []byte
+// being read as the raw uuid.  Any other src, or one that does not parse,
+// returns an error wrapping ErrInvalidUUID.
+func (n *NullUUID) Scan(src any) error {
+	const fname = "NullUUID.Scan"
+	if src == nil {
+		n.UUID, n.Valid = uuid.Nil, false
+		return nil
+	}
+
+	id, err := scanUUID(src)
+	if err != nil {
+		n.UUID, n.Valid = uuid.Nil, false
+		return fmt.Errorf("%s: %w", fname, err)
+	}
+	n.UUID, n.Valid = id, true
+	return nil
+}
+
+// scanUUID parses the string and []byte values that a database driver may
+// return for a uuid column, returning an error wrapping ErrInvalidUUID for
+// any other type or a value that does not hold a uuid.
+func scanUUID(src any) (uuid.UUID, error) {
+	var id uuid.UUID
+	var err error
+	switch src := src.(type) {
+	case string:
+		id, err = uuid.Parse(src)
+	case []byte:
+		if len(src) == 16 {
+			return uuid.FromBytes(src)
+		}
+		id, err = uuid.ParseBytes(src)
+	default:
+		return uuid.Nil, fmt.Errorf("%w: unable to scan type %T into a uuid", ErrInvalidUUID, src)
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: %v", ErrInvalidUUID, err)
+	}
+	return id, nil
+}
+
+// Value implements the driver.Valuer interface, returning nil when the
+// NullUUID is not valid and the canonical string form of the uuid when it
+// is.
+func (n NullUUID) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.UUID.String(), nil
+}
+
+// MarshalJSON implements the json.Marshaler interface, encoding an invalid
+// NullUUID as null and a valid one as the quoted uuid string.
+func (n NullUUID) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.UUID.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, a JSON null
+// sets Valid to false, otherwise data must contain a quoted uuid string,
+// an error wrapping ErrInvalidUUID being returned if it does not.
+func (n *NullUUID) UnmarshalJSON(data []byte) error {
+	const fname = "NullUUID.UnmarshalJSON"
+	if bytes.Equal(data, []byte("null")) {
+		n.UUID, n.Valid = uuid.Nil, false
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("%s: %w: %v", fname, ErrInvalidUUID, err)
+	}
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return fmt.Errorf("%s: %w: %v", fname, ErrInvalidUUID, err)
+	}
+	n.UUID, n.Valid = id, true
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, encoding an
+// invalid NullUUID as empty text and a valid one as the uuid string.
+func (n NullUUID) MarshalText() ([]byte, error) {
+	if !n.Valid {
+		return []byte{}, nil
+	}
+	return n.UUID.MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, empty
+// text sets Valid to false, otherwise data must hold a uuid string, an
+// error wrapping ErrInvalidUUID being returned if it does not.
+func (n *NullUUID) UnmarshalText(data []byte) error {
+	const fname = "NullUUID.UnmarshalText"
+	if len(data) == 0 {
+		n.UUID, n.Valid = uuid.Nil, false
+		return nil
+	}
+	id, err := uuid.ParseBytes(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w: %v", fname, ErrInvalidUUID, err)
+	}
+	n.UUID, n.Valid = id, true
+	return nil
+}
+
+// GobEncode implements the gob.GobEncoder interface, encoding an invalid
+// NullUUID as the single byte 0 and a valid one as the byte 1 followed by
+// the 16 bytes of the uuid.
+func (n NullUUID) GobEncode() ([]byte, error) {
+	if !n.Valid {
+		return []byte{0}, nil
+	}
+	return AppendBinary([]byte{1}, n.UUID), nil
+}
+
+// GobDecode implements the gob.GobDecoder interface, decoding the form
+// written by GobEncode.
+func (n *NullUUID) GobDecode(data []byte) error {
+	const fname = "NullUUID.GobDecode"
+	switch {
+	case len(data) == 1 && data[0] == 0:
+		n.UUID, n.Valid = uuid.Nil, false
+	case len(data) == 17 && data[0] == 1:
+		copy(n.UUID[:], data[1:])
+		n.Valid = true
+	default:
+		return fmt.Errorf("%s: %w, %d bytes of gob data",
+			fname, ErrInvalidUUID, len(data))
+	}
+	return nil
+}
+
+// CombUUID is a uuid that, when scanned from a database, is guaranteed to
+// have been produced by this package, for use with columns that may not be
+// null.
+type CombUUID uuid.UUID
+
+// Scan implements the sql.Scanner interface, parsing src as would
+// NullUUID.Scan and returning an error if the result does not carry the
+// version and variant set by this package.  A nil src returns an error
+// wrapping ErrInvalidUUID, as does one that does not parse.
+func (c *CombUUID) Scan(src any) error {
+	const fname = "CombUUID.Scan"
+	if src == nil {
+		return fmt.Errorf("%s: %w, unable to scan NULL", fname, ErrInvalidUUID)
+	}
+	id, err := scanUUID(src)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fname, err)
+	}
+	if !IsCombUUID(id) {
+		return fmt.Errorf("%s: %w: %v", fname, ErrNotComb, id)
+	}
+	*c = CombUUID(id)
+	return nil
+}
+
+// Value implements the driver.Valuer interface, returning the canonical
+// string form of the uuid.
+func (c CombUUID) Value() (driver.Value, error) {
+	return uuid.UUID(c).String(), nil
+}
@@ -0,0 +1,305 @@
+package comb
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+var (
+	_ sql.Scanner   = (*NullUUID)(nil)
+	_ driver.Valuer = NullUUID{}
+	_ sql.Scanner   = (*CombUUID)(nil)
+	_ driver.Valuer = CombUUID{}
+
+	_ encoding.TextMarshaler   = NullUUID{}
+	_ encoding.TextUnmarshaler = (*NullUUID)(nil)
+	_ gob.GobEncoder           = NullUUID{}
+	_ gob.GobDecoder           = (*NullUUID)(nil)
+)
+
+func TestNullUUIDValue(t *testing.T) {
+	v, err := NullUUID{}.Value()
+	if err != nil {
+		t.Error("did not expect an error:", err)
+	}
+	if v != nil {
+		t.Errorf("want nil got %v", v)
+	}
+
+	id, err := NewTimeStampedUUID()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	v, err = NullUUID{UUID: id, Valid: true}.Value()
+	if err != nil {
+		t.Error("did not expect an error:", err)
+	}
+	if v != id.String() {
+		t.Errorf("want %q got %v", id.String(), v)
+	}
+}
+
+func TestNullUUIDScan(t *testing.T) {
+	id, err := NewTimeStampedUUID()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	tests := []struct {
+		name  string
+		src   any
+		want  NullUUID
+		isErr bool
+	}{
+		{"nil", nil, NullUUID{}, false},
+		{"string", id.String(), NullUUID{id, true}, false},
+		{"bytes", []byte(id.String()), NullUUID{id, true}, false},
+		{"raw bytes", id[:], NullUUID{id, true}, false},
+		{"empty string", "", NullUUID{}, true},
+		{"empty bytes", []byte{}, NullUUID{}, true},
+		{"malformed", "not-a-uuid", NullUUID{}, true},
+		{"wrong type", 42, NullUUID{}, true},
+	}
+	for _, test := range tests {
+		n := NullUUID{UUID: uuid.New(), Valid: true}
+		err := n.Scan(test.src)
+		if test.isErr && !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("%s: want %q got %v", test.name, ErrInvalidUUID, err)
+		}
+		if !test.isErr && err != nil {
+			t.Errorf("%s: did not expect an error: %v", test.name, err)
+		}
+		if n != test.want {
+			t.Errorf("%s: want %v got %v", test.name, test.want, n)
+		}
+	}
+}
+
+func TestNullUUIDRoundTrip(t *testing.T) {
+	id, err := NewTimeStampedUUID()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	for _, want := range []NullUUID{{}, {id, true}} {
+		v, err := want.Value()
+		if err != nil {
+			t.Error("did not expect an error:", err)
+		}
+		var got NullUUID
+		if err := got.Scan(v); err != nil {
+			t.Error("did not expect an error:", err)
+		}
+		if got != want {
+			t.Errorf("want %v got %v", want, got)
+		}
+	}
+}
+
+func TestNullUUIDJSON(t *testing.T) {
+	id, err := NewTimeStampedUUID()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	tests := []struct {
+		in   NullUUID
+		want string
+	}{
+		{NullUUID{}, "null"},
+		{NullUUID{UUID: id}, "null"},
+		{NullUUID{id, true}, `"` + id.String() + `"`},
+	}
+	for _, test := range tests {
+		b, err := json.Marshal(test.in)
+		if err != nil {
+			t.Error("did not expect an error:", err)
+		}
+		if string(b) != test.want {
+			t.Errorf("want %s got %s", test.want, b)
+		}
+		var n NullUUID
+		if err := json.Unmarshal(b, &n); err != nil {
+			t.Error("did not expect an error:", err)
+		}
+		if n.Valid != test.in.Valid || (n.Valid && n.UUID != test.in.UUID) {
+			t.Errorf("want %v got %v", test.in, n)
+		}
+	}
+}
+
+func TestNullUUIDUnmarshalJSONInvalid(t *testing.T) {
+	for _, in := range []string{`"not-a-uuid"`, `""`, `42`, `{}`} {
+		var n NullUUID
+		err := n.UnmarshalJSON([]byte(in))
+		if !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("%s: want %q got %v", in, ErrInvalidUUID, err)
+			continue
+		}
+		if !strings.HasPrefix(err.Error(), "NullUUID.UnmarshalJSON: ") {
+			t.Errorf("%s: want error prefixed with %q got %q", in, "NullUUID.UnmarshalJSON", err)
+		}
+	}
+}
+
+func TestCombUUIDScan(t *testing.T) {
+	id, err := NewTimeStampedUUID()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	var c CombUUID
+	if err := c.Scan(id.String()); err != nil {
+		t.Error("did not expect an error:", err)
+	}
+	if uuid.UUID(c) != id {
+		t.Errorf("want %v got %v", id, uuid.UUID(c))
+	}
+	v, err := c.Value()
+	if err != nil {
+		t.Error("did not expect an error:", err)
+	}
+	if v != id.String() {
+		t.Errorf("want %q got %v", id.String(), v)
+	}
+
+	var d CombUUID
+	if err := d.Scan(id[:]); err != nil || uuid.UUID(d) != id {
+		t.Errorf("want %v got %v %v", id, uuid.UUID(d), err)
+	}
+
+	err = c.Scan(uuid.New().String())
+	if !errors.Is(err, ErrNotComb) {
+		t.Errorf("want %q got %v", ErrNotComb, err)
+	}
+	if uuid.UUID(c) != id {
+		t.Errorf("want %v unchanged got %v", id, uuid.UUID(c))
+	}
+	if err := c.Scan(nil); !errors.Is(err, ErrInvalidUUID) || errors.Is(err, ErrNotComb) {
+		t.Errorf("NULL: want %q got %v", ErrInvalidUUID, err)
+	}
+	for _, src := range []any{"", "not-a-uuid", 42} {
+		if err := c.Scan(src); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("%v: want %q got %v", src, ErrInvalidUUID, err)
+		}
+	}
+	if uuid.UUID(c) != id {
+		t.Errorf("want %v unchanged got %v", id, uuid.UUID(c))
+	}
+}
+
+func TestNullUUIDMatrix(t *testing.T) {
+	id, err := NewTimeStampedUUID()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	tests := []struct {
+		name  string
+		in    NullUUID
+		json  string
+		text  string
+		value driver.Value
+		or    any
+	}{
+		{"zero", NullUUID{}, "null", "", nil, nil},
+		{"invalid", NullUUID{UUID: id}, "null", "", nil, nil},
+		{"valid", NewNullUUID(id), `"` + id.String() + `"`, id.String(), id.String(), id},
+	}
+	for _, test := range tests {
+		b, err := json.Marshal(test.in)
+		if err != nil || string(b) != test.json {
+			t.Errorf("%s: json want %s got %s %v", test.name, test.json, b, err)
+		}
+		b, err = test.in.MarshalText()
+		if err != nil || string(b) != test.text {
+			t.Errorf("%s: text want %q got %q %v", test.name, test.text, b, err)
+		}
+		v, err := test.in.Value()
+		if err != nil || v != test.value {
+			t.Errorf("%s: value want %v got %v %v", test.name, test.value, v, err)
+		}
+		if or := test.in.ValueOrNil(); or != test.or {
+			t.Errorf("%s: value or nil want %v got %v", test.name, test.or, or)
+		}
+
+		var n NullUUID
+		if err := n.UnmarshalText([]byte(test.text)); err != nil {
+			t.Errorf("%s: did not expect an error: %v", test.name, err)
+		}
+		if n.Valid != test.in.Valid || (n.Valid && n.UUID != test.in.UUID) {
+			t.Errorf("%s: text round trip want %v got %v", test.name, test.in, n)
+		}
+	}
+
+	var n NullUUID
+	if err := n.UnmarshalText([]byte("not-a-uuid")); !errors.Is(err, ErrInvalidUUID) {
+		t.Errorf("want %q got %v", ErrInvalidUUID, err)
+	}
+}
+
+func TestNullUUIDXML(t *testing.T) {
+	type record struct {
+		ID NullUUID `xml:"id"`
+	}
+	id, err := NewTimeStampedUUID()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	for _, want := range []record{{}, {NewNullUUID(id)}} {
+		b, err := xml.Marshal(want)
+		if err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		var got record
+		if err := xml.Unmarshal(b, &got); err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		if got != want {
+			t.Errorf("%s: want %v got %v", b, want, got)
+		}
+	}
+}
+
+func TestNullUUIDGob(t *testing.T) {
+	type record struct {
+		ID NullUUID
+	}
+	id, err := NewTimeStampedUUID()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	// A valid NullUUID of uuid.Nil must not decode as invalid.
+	for _, want := range []record{{}, {NewNullUUID(id)}, {NewNullUUID(uuid.Nil)}} {
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(want); err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		var got record
+		if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		if got != want {
+			t.Errorf("want %v got %v", want, got)
+		}
+	}
+
+	b, _ := NullUUID{}.GobEncode()
+	if len(b) != 1 {
+		t.Errorf("want a single byte for an invalid NullUUID got %x", b)
+	}
+	n := NewNullUUID(id)
+	if err := n.GobDecode(b); err != nil || n.Valid {
+		t.Errorf("want an invalid NullUUID got %v %v", n, err)
+	}
+	for _, data := range [][]byte{nil, {2}, {1, 0, 0}, append([]byte{0}, id[:]...)} {
+		if err := n.GobDecode(data); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("%x: want %q got %v", data, ErrInvalidUUID, err)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package comb
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewWithPayload(t *testing.T) {
+	payloads := [][]byte{
+		nil,
+		{0x01},
+		{0xde, 0xad, 0xbe, 0xef},
+		{0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+	}
+	for _, payload := range payloads {
+		id, err := NewWithPayload(payload)
+		if err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		if got := ReadPayload(id, len(payload)); !bytes.Equal(got, payload) {
+			t.Errorf("want %x got %x", payload, got)
+		}
+		if err := Validate(id); err != nil {
+			t.Error("did not expect an error:", err)
+		}
+		if d := time.Since(ParseTime(id)); d > time.Second || d < -time.Second {
+			t.Errorf("want a time close to now got %v", ParseTime(id))
+		}
+	}
+
+	_, err := NewWithPayload(make([]byte, MaxPayload+1))
+	if !errors.Is(err, ErrTooManyBytes) {
+		t.Errorf("want ErrTooManyBytes got %v", err)
+	}
+	if got := ReadPayload([16]byte{1, 2, 3, 4, 5, 6, 7}, 10); len(got) != MaxPayload {
+		t.Errorf("want %d bytes got %x", MaxPayload, got)
+	}
+}
+
+func TestNewWithEntropyBytes(t *testing.T) {
+	for n := 0; n <= 10; n++ {
+		id, err := NewWithEntropyBytes(n)
+		if err != nil {
+			t.Fatalf("%d: did not expect an error: %v", n, err)
+		}
+		if err := Validate(id); err != nil {
+			t.Errorf("%d: did not expect an error: %v", n, err)
+		}
+		// Beyond n the bytes are zero but for the version and variant.
+		cleared := ClearRFCBits(id)
+		for i := n; i < 10; i++ {
+			if cleared[i] != 0 {
+				t.Errorf("%d: want byte %d zero got %v", n, i, id)
+			}
+		}
+		if d := time.Since(ParseTime(id)); d > time.Second || d < -time.Second {
+			t.Errorf("%d: want a time close to now got %v", n, ParseTime(id))
+		}
+	}
+
+	if _, err := NewWithEntropyBytes(-1); !errors.Is(err, ErrTooFewBytes) {
+		t.Errorf("want ErrTooFewBytes got %v", err)
+	}
+	if _, err := NewWithEntropyBytes(11); !errors.Is(err, ErrTooManyBytes) {
+		t.Errorf("want ErrTooManyBytes got %v", err)
+	}
+}
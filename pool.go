@@ -0,0 +1,61 @@
+package comb
+
+import (
+	"crypto/rand"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// poolBatch is the number of UUIDs worth of random data that a pooled
+// entropy buffer reads from crypto/rand at a time.
+const poolBatch = 32
+
+// entropy is a buffer of random data read ahead from crypto/rand.  As a
+// buffer is only ever held by one goroutine at a time, taken from and
+// returned to entropyPool, each byte is handed out once only.
+type entropy struct {
+	buf [poolBatch * 10]byte
+	off int // Offset of the unused data in buf.
+}
+
+var entropyPool = sync.Pool{
+	New: func() any {
+		e := new(entropy)
+		e.off = len(e.buf)
+		return e
+	},
+}
+
+// read fills p, which may not exceed the length of the buffer, with random
+// data, refilling the buffer from crypto/rand when it runs short.
+func (e *entropy) read(p []byte) error {
+	if e.off+len(p) > len(e.buf) {
+		if _, err := io.ReadFull(rand.Reader, e.buf[:]); err != nil {
+			return readError{err}
+		}
+		e.off = 0
+	}
+	e.off += copy(p, e.buf[e.off:])
+	return nil
+}
+
+// pooledTimeStampedUUID returns a UUID in the layout of NewTimeStampedUUID,
+// stamped with the time t, whose random data is taken from a pooled
+// entropy buffer.
+func pooledTimeStampedUUID(t uuid.Time) (uuid.UUID, error) {
+	const nBytes = 6
+	var id uuid.UUID
+	e := entropyPool.Get().(*entropy)
+	err := e.read(id[:len(id)-nBytes])
+	entropyPool.Put(e)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	uint64ToBytes(id[len(id)-nBytes:], nBytes, toTicks(t, time.Millisecond/10)&(1<<(nBytes*8)-1))
+	setRFCBits(&id)
+	return id, nil
+}
@@ -0,0 +1,82 @@
+package comb
+
+import (
+	"crypto/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewTimeStampedUUIDConcurrent(t *testing.T) {
+	const workers, n = 16, 1000
+	ids := make([][]uuid.UUID, workers)
+	var wg sync.WaitGroup
+	for w := range ids {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				id, err := NewTimeStampedUUID()
+				if err != nil {
+					t.Error("did not expect an error:", err)
+					return
+				}
+				ids[w] = append(ids[w], id)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	seen := make(map[[10]byte]bool)
+	for _, batch := range ids {
+		for _, id := range batch {
+			if err := Validate(id); err != nil {
+				t.Error("did not expect an error:", err)
+			}
+			var r [10]byte
+			copy(r[:], id[:10])
+			if seen[r] {
+				t.Fatalf("repeated random data %x", r)
+			}
+			seen[r] = true
+		}
+	}
+}
+
+func TestNewTimeStampedUUIDAllocs(t *testing.T) {
+	n := testing.AllocsPerRun(1000, func() {
+		if _, err := NewTimeStampedUUID(); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if n != 0 {
+		t.Errorf("want 0 allocations got %v", n)
+	}
+}
+
+// BenchmarkNewTimeStampedUUIDParallel measures the pooled default
+// generator, run with -cpu=8 to compare with the unpooled benchmark below.
+func BenchmarkNewTimeStampedUUIDParallel(b *testing.B) {
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := NewTimeStampedUUID(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkCustomTimeStampedUUIDParallel(b *testing.B) {
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			now, _, _ := uuid.GetTime()
+			if _, err := CustomTimeStampedUUID(rand.Reader, 6, now, time.Millisecond/10, true); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
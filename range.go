@@ -0,0 +1,96 @@
+package comb
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FilterByTimeRange returns those of the TimeStampedUUIDs ids whose
+// decoded time stamp falls within [start, end), in their original order.
+func FilterByTimeRange(ids []uuid.UUID, start, end time.Time) []uuid.UUID {
+	var out []uuid.UUID
+	for _, id := range ids {
+		t := ParseTime(id)
+		if !t.Before(start) && t.Before(end) {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// BoundsForRange returns the smallest SortableUUID stamped at start and
+// the smallest stamped at end, so that in byte order lo <= id < hi holds
+// for every SortableUUID whose time stamp falls within [start, end), as is
+// needed for a range query in SQL.  As time stamps are rounded to the
+// nearest 10th of a millisecond, start and end are exact only when they
+// are themselves multiples of the resolution.
+func BoundsForRange(start, end time.Time) (lo, hi uuid.UUID) {
+	return minSortable(start), minSortable(end)
+}
+
+// PrefixRange returns the 6 byte time stamp prefixes of the SortableUUIDs
+// stamped at start and at end, so that every SortableUUID stamped within
+// [start, end) has a key of at least loPrefix and less than hiPrefix, for
+// a prefix scan over the keys of a store such as BoltDB.  As for
+// BoundsForRange the bounds are exact only for multiples of the
+// resolution.
+func PrefixRange(start, end time.Time) (loPrefix, hiPrefix []byte) {
+	lo, hi := BoundsForRange(start, end)
+	return lo[:6], hi[:6]
+}
+
+// MinForTime returns the smallest SortableUUID stamped at t, all of its
+// random bits being zero, an inclusive lower bound for a SQL BETWEEN over
+// the UUIDs of the tick in which t falls.
+func MinForTime(t time.Time) uuid.UUID {
+	return minSortable(t)
+}
+
+// MaxForTime returns the largest SortableUUID stamped at t, all of its
+// random bits being set, an inclusive upper bound for a SQL BETWEEN over
+// the UUIDs of the tick in which t falls.
+func MaxForTime(t time.Time) uuid.UUID {
+	id := minSortable(t)
+	for i := 6; i < len(id); i++ {
+		id[i] = 0xff
+	}
+	setRFCBits(&id)
+	return id
+}
+
+// minSortable returns the smallest SortableUUID with the time stamp t.
+func minSortable(t time.Time) uuid.UUID {
+	var id uuid.UUID
+	mask := uint64(1<<48 - 1)
+	uint64ToBytes(id[:6], 6, toTicks(UnixToUUIDTime(t), time.Millisecond/10)&mask)
+	setRFCBits(&id)
+	return id
+}
+
+// TimeHistogram counts the TimeStampedUUIDs ids by their decoded time
+// stamp, truncated to a multiple of bucket, giving a profile of the rate
+// at which they were created.  An empty slice gives an empty map.
+func TimeHistogram(ids []uuid.UUID, bucket time.Duration) map[time.Time]int {
+	hist := make(map[time.Time]int)
+	for _, id := range ids {
+		hist[ParseTime(id).Truncate(bucket)]++
+	}
+	return hist
+}
+
+// NextKey returns the smallest uuid that is strictly greater than id in
+// byte order, id incremented as a 128 bit big endian integer, for use as
+// an exclusive pagination cursor.  The largest uuid, all bytes 0xff, has
+// no successor and is returned unchanged.  The result need not carry the
+// version and variant of this package.
+func NextKey(id uuid.UUID) uuid.UUID {
+	next := id
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			return next
+		}
+	}
+	return id
+}
@@ -0,0 +1,168 @@
+package comb
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestFilterByTimeRange(t *testing.T) {
+	t0 := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	var ids []uuid.UUID
+	for i := 0; i < 10; i++ {
+		id, err := NewTimeStampedUUIDAt(t0.Add(time.Duration(i) * time.Minute))
+		if err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		ids = append(ids, id)
+	}
+
+	got := FilterByTimeRange(ids, t0.Add(2*time.Minute), t0.Add(5*time.Minute))
+	want := ids[2:5]
+	if len(got) != len(want) {
+		t.Fatalf("want %d got %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("%d: want %v got %v", i, want[i], got[i])
+		}
+	}
+	if got := FilterByTimeRange(nil, t0, t0.Add(time.Hour)); len(got) != 0 {
+		t.Errorf("want none got %v", got)
+	}
+}
+
+func TestBoundsForRange(t *testing.T) {
+	const res = time.Millisecond / 10
+	t0 := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	start, end := t0.Add(2*time.Minute), t0.Add(5*time.Minute)
+	lo, hi := BoundsForRange(start, end)
+
+	for i := 0; i < 10; i++ {
+		when := t0.Add(time.Duration(i) * time.Minute)
+		for _, d := range []time.Duration{0, res, time.Minute - res} {
+			id, err := CustomSortableUUID(rand.Reader, 6, UnixToUUIDTime(when.Add(d)), res, true)
+			if err != nil {
+				t.Fatal("did not expect an error:", err)
+			}
+			in := bytes.Compare(id[:], lo[:]) >= 0 && bytes.Compare(id[:], hi[:]) < 0
+			want := !when.Add(d).Before(start) && when.Add(d).Before(end)
+			if in != want {
+				t.Errorf("%v: want in range %t got %t", when.Add(d), want, in)
+			}
+		}
+	}
+}
+
+func TestTimeHistogram(t *testing.T) {
+	t0 := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	var ids []uuid.UUID
+	for _, d := range []time.Duration{0, time.Second, 59 * time.Second, time.Minute, 90 * time.Second} {
+		id, err := NewTimeStampedUUIDAt(t0.Add(d))
+		if err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		ids = append(ids, id)
+	}
+	hist := TimeHistogram(ids, time.Minute)
+	want := map[time.Time]int{t0: 3, t0.Add(time.Minute): 2}
+	if len(hist) != len(want) {
+		t.Errorf("want %v got %v", want, hist)
+	}
+	for k, v := range want {
+		if hist[k] != v {
+			t.Errorf("%v: want %d got %d", k, v, hist[k])
+		}
+	}
+
+	if hist := TimeHistogram(nil, time.Minute); hist == nil || len(hist) != 0 {
+		t.Errorf("want an empty map got %v", hist)
+	}
+}
+
+func TestNextKey(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"00000000-0000-0000-0000-000000000000", "00000000-0000-0000-0000-000000000001"},
+		{"0123abcd-4567-6def-e123-456789abcdef", "0123abcd-4567-6def-e123-456789abcdf0"},
+		{"0123abcd-4567-6def-e123-4567890000ff", "0123abcd-4567-6def-e123-456789000100"},
+		{"0123abcd-4567-6def-e0ff-ffffffffffff", "0123abcd-4567-6def-e100-000000000000"},
+		{"00ffffff-ffff-ffff-ffff-ffffffffffff", "01000000-0000-0000-0000-000000000000"},
+		{"ffffffff-ffff-ffff-ffff-ffffffffffff", "ffffffff-ffff-ffff-ffff-ffffffffffff"},
+	}
+	for _, test := range tests {
+		in := uuid.MustParse(test.in)
+		got := NextKey(in)
+		if want := uuid.MustParse(test.want); got != want {
+			t.Errorf("%s: want %v got %v", test.in, want, got)
+		}
+		if got != in && bytes.Compare(got[:], in[:]) <= 0 {
+			t.Errorf("%s: want a greater key got %v", test.in, got)
+		}
+	}
+}
+
+func TestPrefixRange(t *testing.T) {
+	const res = time.Millisecond / 10
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	lo, hi := PrefixRange(start, end)
+	if len(lo) != 6 || len(hi) != 6 {
+		t.Fatalf("want 6 byte prefixes got %x %x", lo, hi)
+	}
+
+	within := []time.Time{start, start.Add(30 * time.Minute), end.Add(-res)}
+	for _, when := range within {
+		id, err := CustomSortableUUID(rand.Reader, 6, UnixToUUIDTime(when), res, true)
+		if err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		if bytes.Compare(id[:], lo) < 0 || bytes.Compare(id[:], hi) >= 0 {
+			t.Errorf("%v: want %v within [%x, %x)", when, id, lo, hi)
+		}
+	}
+	for _, when := range []time.Time{start.Add(-res), end} {
+		id, _ := CustomSortableUUID(rand.Reader, 6, UnixToUUIDTime(when), res, true)
+		if bytes.Compare(id[:], lo) >= 0 && bytes.Compare(id[:], hi) < 0 {
+			t.Errorf("%v: want %v outside [%x, %x)", when, id, lo, hi)
+		}
+	}
+}
+
+func TestMinMaxForTime(t *testing.T) {
+	const res = time.Millisecond / 10
+	when := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	lo, hi := MinForTime(when), MaxForTime(when)
+	for _, id := range []uuid.UUID{lo, hi} {
+		if err := Validate(id); err != nil {
+			t.Error("did not expect an error:", err)
+		}
+		if got := ReadLeadingTimeStamp(id); got != toTicks(UnixToUUIDTime(when), res) {
+			t.Errorf("want the time stamp of %v got %d", when, got)
+		}
+	}
+	if want := uuid.MustParse(lo.String()[:14] + "6fff-ffff-ffffffffffff"); hi != want {
+		t.Errorf("want %v got %v", want, hi)
+	}
+
+	for i := 0; i < 100; i++ {
+		id, err := CustomSortableUUID(rand.Reader, 6, UnixToUUIDTime(when), res, true)
+		if err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		if bytes.Compare(id[:], lo[:]) < 0 || bytes.Compare(id[:], hi[:]) > 0 {
+			t.Errorf("want %v within [%v, %v]", id, lo, hi)
+		}
+	}
+
+	// The neighbouring ticks fall outside.
+	before, _ := CustomSortableUUID(rand.Reader, 6, UnixToUUIDTime(when.Add(-res)), res, true)
+	after, _ := CustomSortableUUID(rand.Reader, 6, UnixToUUIDTime(when.Add(res)), res, true)
+	if bytes.Compare(before[:], lo[:]) >= 0 || bytes.Compare(after[:], hi[:]) <= 0 {
+		t.Errorf("want %v and %v outside [%v, %v]", before, after, lo, hi)
+	}
+}
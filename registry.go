@@ -0,0 +1,84 @@
+package comb
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Encoding is a string form of a uuid.
+type Encoding interface {
+	Encode(id uuid.UUID) string
+	Decode(s string) (uuid.UUID, error)
+}
+
+// base32Encoding is the Crockford base32 form of EncodeBase32.
+type base32Encoding struct{}
+
+func (base32Encoding) Encode(id uuid.UUID) string         { return EncodeBase32(id) }
+func (base32Encoding) Decode(s string) (uuid.UUID, error) { return DecodeBase32(s) }
+
+// hexEncoding is the dashless hex form of Hex.
+type hexEncoding struct{}
+
+func (hexEncoding) Encode(id uuid.UUID) string         { return Hex(id) }
+func (hexEncoding) Decode(s string) (uuid.UUID, error) { return FromHex(s) }
+
+// encodings holds the registered Encodings by name.
+var encodings = struct {
+	sync.RWMutex
+	m map[string]Encoding
+}{m: map[string]Encoding{
+	"base32": base32Encoding{},
+	"hex":    hexEncoding{},
+}}
+
+// RegisterEncoding makes e available to Encode and Decode under name,
+// replacing any Encoding already registered under it.  Two are registered
+// from the start, "base32", as EncodeBase32, and "hex", as Hex.
+// RegisterEncoding panics should e be nil.
+func RegisterEncoding(name string, e Encoding) {
+	if e == nil {
+		panic("comb: RegisterEncoding: nil Encoding for " + name)
+	}
+	encodings.Lock()
+	encodings.m[name] = e
+	encodings.Unlock()
+}
+
+// lookupEncoding returns the Encoding registered under name.
+func lookupEncoding(name string) (Encoding, error) {
+	encodings.RLock()
+	e, ok := encodings.m[name]
+	encodings.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownEncoding, name)
+	}
+	return e, nil
+}
+
+// Encode returns id in the form of the Encoding registered under name, an
+// error wrapping ErrUnknownEncoding being returned should there be none.
+func Encode(name string, id uuid.UUID) (string, error) {
+	e, err := lookupEncoding(name)
+	if err != nil {
+		return "", fmt.Errorf("Encode: %w", err)
+	}
+	return e.Encode(id), nil
+}
+
+// Decode returns the uuid held in s in the form of the Encoding registered
+// under name, an error wrapping ErrUnknownEncoding being returned should
+// there be none.
+func Decode(name string, s string) (uuid.UUID, error) {
+	e, err := lookupEncoding(name)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("Decode: %w", err)
+	}
+	id, err := e.Decode(s)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("Decode: %w", err)
+	}
+	return id, nil
+}
@@ -0,0 +1,65 @@
+package comb
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// upperEncoding is the canonical string form in upper case.
+type upperEncoding struct{}
+
+func (upperEncoding) Encode(id uuid.UUID) string         { return strings.ToUpper(id.String()) }
+func (upperEncoding) Decode(s string) (uuid.UUID, error) { return uuid.Parse(s) }
+
+func TestEncodingRegistry(t *testing.T) {
+	id, err := NewTimeStampedUUID()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	RegisterEncoding("upper", upperEncoding{})
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"base32", EncodeBase32(id)},
+		{"hex", Hex(id)},
+		{"upper", strings.ToUpper(id.String())},
+	}
+	for _, test := range tests {
+		s, err := Encode(test.name, id)
+		if err != nil {
+			t.Errorf("%s: did not expect an error: %v", test.name, err)
+		}
+		if s != test.want {
+			t.Errorf("%s: want %s got %s", test.name, test.want, s)
+		}
+		got, err := Decode(test.name, s)
+		if err != nil {
+			t.Errorf("%s: did not expect an error: %v", test.name, err)
+		}
+		if got != id {
+			t.Errorf("%s: want %v got %v", test.name, id, got)
+		}
+	}
+
+	if _, err := Encode("base58", id); !errors.Is(err, ErrUnknownEncoding) {
+		t.Errorf("want %q got %v", ErrUnknownEncoding, err)
+	}
+	if _, err := Decode("base58", "x"); !errors.Is(err, ErrUnknownEncoding) {
+		t.Errorf("want %q got %v", ErrUnknownEncoding, err)
+	}
+	if _, err := Decode("hex", "x"); !errors.Is(err, ErrInvalidUUID) {
+		t.Errorf("want %q got %v", ErrInvalidUUID, err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic")
+		}
+	}()
+	RegisterEncoding("nil", nil)
+}
@@ -0,0 +1,104 @@
+package comb
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reservoir generates UUIDs in the same layout as NewTimeStampedUUID from
+// blocks of random data read ahead from crypto/rand by a background
+// goroutine, so that New need not wait on the random source so long as
+// the reservoir has not been drained.  Close stops the goroutine.
+//
+// A Reservoir is safe for concurrent use.
+type Reservoir struct {
+	blocks  chan [10]byte
+	done    chan struct{}
+	stopped chan struct{}
+	once    sync.Once
+	err     error // Set by fill before blocks is closed.
+}
+
+// NewReservoir returns a Reservoir that holds up to size blocks of random
+// data, one per UUID, size being at least 1.
+func NewReservoir(size int) *Reservoir {
+	return newReservoir(rand.Reader, size)
+}
+
+func newReservoir(r io.Reader, size int) *Reservoir {
+	if size < 1 {
+		size = 1
+	}
+	res := &Reservoir{
+		blocks:  make(chan [10]byte, size),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go res.fill(r)
+	return res
+}
+
+// fill keeps the reservoir topped up from r until it is closed or r
+// fails, at which point blocks is closed.
+func (res *Reservoir) fill(r io.Reader) {
+	defer close(res.stopped)
+	defer close(res.blocks)
+	var buf [poolBatch * 10]byte
+	for {
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			res.err = readError{err}
+			return
+		}
+		for i := 0; i < len(buf); i += 10 {
+			var b [10]byte
+			copy(b[:], buf[i:])
+			select {
+			case res.blocks <- b:
+			case <-res.done:
+				return
+			}
+		}
+	}
+}
+
+// New returns a UUID stamped with the current time whose random data is
+// taken from the reservoir.  An error wrapping ErrClosed is returned once
+// the Reservoir is closed, or that of the random source should it fail.
+func (res *Reservoir) New() (uuid.UUID, error) {
+	const fname = "Reservoir.New"
+	select {
+	case <-res.done:
+		return uuid.Nil, fmt.Errorf("%s: %w", fname, ErrClosed)
+	default:
+	}
+	b, ok := <-res.blocks
+	if !ok {
+		if res.err != nil {
+			return uuid.Nil, fmt.Errorf("%s: %w", fname, res.err)
+		}
+		return uuid.Nil, fmt.Errorf("%s: %w", fname, ErrClosed)
+	}
+	now, _, err := uuid.GetTime()
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", fname, err)
+	}
+
+	var id uuid.UUID
+	copy(id[:], b[:])
+	uint64ToBytes(id[10:], 6, toTicks(now, time.Millisecond/10))
+	setRFCBits(&id)
+	return id, nil
+}
+
+// Close stops the background goroutine, waiting for it to exit.  Close
+// may be called more than once.
+func (res *Reservoir) Close() error {
+	res.once.Do(func() { close(res.done) })
+	<-res.stopped
+	return nil
+}
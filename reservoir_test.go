@@ -0,0 +1,66 @@
+package comb
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReservoir(t *testing.T) {
+	before := runtime.NumGoroutine()
+	res := NewReservoir(64)
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				id, err := res.New()
+				if err != nil {
+					t.Error("did not expect an error:", err)
+					return
+				}
+				if err := Validate(id); err != nil {
+					t.Error("did not expect an error:", err)
+				}
+				if d := time.Since(ParseTime(id)); d > time.Second || d < -time.Second {
+					t.Errorf("want a time close to now got %v", ParseTime(id))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := res.Close(); err != nil {
+		t.Error("did not expect an error:", err)
+	}
+	if err := res.Close(); err != nil {
+		t.Error("did not expect an error:", err)
+	}
+	if _, err := res.New(); !errors.Is(err, ErrClosed) {
+		t.Errorf("want ErrClosed got %v", err)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("want no more than %d goroutines got %d", before, after)
+	}
+}
+
+func TestReservoirReadError(t *testing.T) {
+	// One batch, then the source fails.
+	res := newReservoir(bytes.NewReader(make([]byte, poolBatch*10)), 4)
+	defer res.Close()
+	for i := 0; i < poolBatch; i++ {
+		if _, err := res.New(); err != nil {
+			t.Fatalf("%d: did not expect an error: %v", i, err)
+		}
+	}
+	_, err := res.New()
+	if !errors.Is(err, ErrShortRead) || !errors.Is(err, io.EOF) {
+		t.Errorf("want ErrShortRead and io.EOF got %v", err)
+	}
+}
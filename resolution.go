@@ -0,0 +1,115 @@
+package comb
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Resolution is a time stamp resolution paired with the width in bytes of
+// the time stamp recommended for it.
+type Resolution struct {
+	Duration time.Duration
+	NBytes   int
+}
+
+// Resolution presets, the span given being the range that each covers,
+// as given by TimeRange, from 15 Oct 1582 before wrapping.
+var (
+	// ResolutionMicro stamps 7 bytes to the micro second, a span of 2283
+	// years.
+	ResolutionMicro = Resolution{time.Microsecond, 7}
+
+	// ResolutionTenthMilli stamps 6 bytes to the 10th of a millisecond, a
+	// span of 891 years, the layout of NewTimeStampedUUID.
+	ResolutionTenthMilli = Resolution{time.Millisecond / 10, 6}
+
+	// ResolutionMilli stamps 6 bytes to the millisecond, a span of 8919
+	// years.
+	ResolutionMilli = Resolution{time.Millisecond, 6}
+)
+
+// minSpan is the shortest span that NewWithResolution will accept for a
+// time stamp, 800 years from 15 Oct 1582 reaching into the 24th century.
+const minSpan = 800 * 365.24219 * 24 * float64(time.Hour)
+
+// bytesFor returns the smallest time stamp width, within the limit set by
+// the rfc4122 version and variant bits, whose range covers minSpan at the
+// resolution res.
+func bytesFor(res time.Duration) (int, error) {
+	return widthFor(minSpan, res, maxRFCTimeStampBytes)
+}
+
+// widthFor returns the smallest time stamp width, of no more than max
+// bytes, whose range covers span nano seconds at the resolution res.
+func widthFor(span float64, res time.Duration, max int) (int, error) {
+	for n := 1; n <= max; n++ {
+		if math.Ldexp(float64(ticks(res)*100), 8*n) >= span {
+			return n, nil
+		}
+	}
+	return 0, fmt.Errorf("%w, a resolution of %v requires more than %d",
+		ErrTooManyBytes, res, max)
+}
+
+// BytesNeeded returns the smallest time stamp width in bytes whose range,
+// as given by TimeRange, covers span at the resolution res.  An error is
+// returned should more than 8 bytes be required.  The result applies only
+// to time stamps measured from a custom epoch, as written by NewWithEpoch
+// and read by ReadTimeStampWithEpoch, span being the time from the epoch
+// to the last time to be stamped; a time.Duration of at most some 292
+// years can not reach the present from 15 Oct 1582, the epoch of
+// CustomTimeStampedUUID, for which NewWithResolution chooses the width.
+func BytesNeeded(span time.Duration, res time.Duration) (int, error) {
+	n, err := widthFor(float64(span), res, maxTimeStampBytes)
+	if err != nil {
+		return 0, fmt.Errorf("BytesNeeded: %w", err)
+	}
+	return n, nil
+}
+
+// EffectiveResolution returns the length of time actually represented by
+// one unit of a time stamp of nBytes written at the resolution res, when
+// it must cover span without wrapping.  Time stamps are stored as a whole
+// number of 100 nano second uuid.Time ticks, so that res is rounded down
+// to a multiple of 100ns, and coarsened further should nBytes at that
+// resolution not reach across span.  nBytes is limited to between 1 and 8.
+func EffectiveResolution(nBytes int, res time.Duration, span time.Duration) time.Duration {
+	if nBytes < 1 {
+		nBytes = 1
+	}
+	if nBytes > maxTimeStampBytes {
+		nBytes = maxTimeStampBytes
+	}
+	t := ticks(res)
+	need := math.Ceil(float64(span) / 100 / math.Ldexp(1, 8*nBytes))
+	if need > float64(t) {
+		t = uint64(need)
+	}
+	return time.Duration(t) * 100
+}
+
+// NewWithResolution returns a UUID stamped with the current time at the
+// resolution res, using the smallest time stamp width whose range extends
+// at least 800 years from 15 Oct 1582, the remaining bytes being
+// cryptographically random.  The width used is that of the matching
+// Resolution preset.
+func NewWithResolution(res time.Duration) (uuid.UUID, error) {
+	const fname = "NewWithResolution"
+	nBytes, err := bytesFor(res)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", fname, err)
+	}
+	now, _, err := uuid.GetTime()
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", fname, err)
+	}
+	id, err := CustomTimeStampedUUID(rand.Reader, nBytes, now, res, true)
+	if err != nil {
+		return id, fmt.Errorf("%s: %w", fname, err)
+	}
+	return id, nil
+}
@@ -0,0 +1,132 @@
+package comb
+
+import (
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestResolutionPresets(t *testing.T) {
+	tests := []struct {
+		res   Resolution
+		years int64
+	}{
+		{ResolutionMicro, 2283},
+		{ResolutionTenthMilli, 891},
+		{ResolutionMilli, 8919},
+	}
+	for _, test := range tests {
+		res := test.res
+		n, err := bytesFor(res.Duration)
+		if err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		if n != res.NBytes {
+			t.Errorf("%v: want %d bytes got %d", res.Duration, res.NBytes, n)
+		}
+		if err := checkWidth(res.NBytes, true); err != nil {
+			t.Errorf("%v: did not expect an error: %v", res.Duration, err)
+		}
+		span := TimeRange(uint64(res.NBytes*8), res.Duration)
+		if span.Years != test.years {
+			t.Errorf("%v: want %d years got %v", res.Duration, test.years, span)
+		}
+
+		want := time.Date(2024, 6, 1, 12, 0, 0, 123456789, time.UTC)
+		id, err := CustomTimeStampedUUID(rand.Reader, res.NBytes, UnixToUUIDTime(want), res.Duration, true)
+		if err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		got := ParseCustomTime(id, res.NBytes, res.Duration)
+		if d := got.Sub(want); d > res.Duration || d < -res.Duration {
+			t.Errorf("%v: want %v got %v", res.Duration, want, got)
+		}
+
+		want = time.Now()
+		id, err = NewWithResolution(res.Duration)
+		if err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		if err := Validate(id); err != nil {
+			t.Error("did not expect an error:", err)
+		}
+		got = ParseCustomTime(id, res.NBytes, res.Duration)
+		if d := got.Sub(want); d > res.Duration+time.Second || d < -res.Duration-time.Second {
+			t.Errorf("%v: want %v got %v", res.Duration, want, got)
+		}
+	}
+}
+
+func TestNewWithResolutionTooFine(t *testing.T) {
+	_, err := NewWithResolution(100 * time.Nanosecond)
+	if !errors.Is(err, ErrTooManyBytes) {
+		t.Errorf("want %q got %v", ErrTooManyBytes, err)
+	}
+}
+
+func TestBytesNeeded(t *testing.T) {
+	const year = 8766 * time.Hour
+	tests := []struct {
+		span time.Duration
+		res  time.Duration
+		want int
+	}{
+		{time.Second, time.Second, 1},
+		{256 * time.Second, time.Second, 1},
+		{257 * time.Second, time.Second, 2},
+		{3 * year, time.Millisecond / 10, 5},
+		{200 * year, time.Millisecond / 10, 6},
+		{200 * year, time.Microsecond, 7},
+		{250 * year, 100 * time.Nanosecond, 8},
+	}
+	for _, test := range tests {
+		n, err := BytesNeeded(test.span, test.res)
+		if err != nil {
+			t.Errorf("%v at %v: did not expect an error: %v", test.span, test.res, err)
+		}
+		if n != test.want {
+			t.Errorf("%v at %v: want %d got %d", test.span, test.res, test.want, n)
+		}
+	}
+
+	// The width holds the span from a custom epoch without wrapping.
+	const res = time.Millisecond / 10
+	epoch := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	n, err := BytesNeeded(200*year, res)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	last := epoch.Add(200*year - res)
+	id, err := CustomTimeStampedUUID(rand.Reader, n, UnixToUUIDTime(last)-UnixToUUIDTime(epoch), res, true)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if got := ReadTimeStampWithEpoch(id, n, res, epoch); !got.Equal(last) {
+		t.Errorf("want %v got %v", last, got)
+	}
+}
+
+func TestEffectiveResolution(t *testing.T) {
+	const year = 8766 * time.Hour
+	tests := []struct {
+		nBytes int
+		res    time.Duration
+		span   time.Duration
+		want   time.Duration
+	}{
+		// The default configuration.
+		{6, time.Millisecond / 10, 200 * year, time.Millisecond / 10},
+		// Rounded down to whole 100ns ticks.
+		{6, 150, time.Hour, 100},
+		{8, 1, year, 100},
+		// Coarsened so as to cover the span.
+		{6, time.Microsecond, 1 << 48 * 10 * time.Microsecond, 10 * time.Microsecond},
+		{1, time.Second, 512 * time.Second, 2 * time.Second},
+	}
+	for _, test := range tests {
+		if got := EffectiveResolution(test.nBytes, test.res, test.span); got != test.want {
+			t.Errorf("%d bytes at %v over %v: want %v got %v", test.nBytes, test.res, test.span, test.want, got)
+		}
+	}
+}
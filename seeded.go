@@ -0,0 +1,21 @@
+package comb
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NewSeeded returns a UUID in the same layout as NewTimeStampedUUID,
+// stamped with the time t, whose random data is drawn from a math/rand
+// source seeded with seed, so that the same seed and time always produce
+// the same UUID.  The data is NOT cryptographically random, NewSeeded is
+// intended for reproducible test fixtures only.
+func NewSeeded(seed int64, t time.Time) uuid.UUID {
+	// A math/rand source never fails to read and the width is valid, so
+	// there is no error to return.
+	r := rand.New(rand.NewSource(seed))
+	id, _ := CustomTimeStampedUUID(r, 6, UnixToUUIDTime(t), time.Millisecond/10, true)
+	return id
+}
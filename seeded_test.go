@@ -0,0 +1,27 @@
+package comb
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewSeeded(t *testing.T) {
+	when := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	a := NewSeeded(42, when)
+	b := NewSeeded(42, when)
+	if a != b {
+		t.Errorf("want %v got %v", a, b)
+	}
+	if err := Validate(a); err != nil {
+		t.Error("did not expect an error:", err)
+	}
+	if got := ParseTime(a); !got.Equal(when) {
+		t.Errorf("want %v got %v", when, got)
+	}
+	if c := NewSeeded(43, when); c == a {
+		t.Errorf("want a different uuid for a different seed got %v", c)
+	}
+	if c := NewSeeded(42, when.Add(time.Second)); c == a {
+		t.Errorf("want a different uuid for a different time got %v", c)
+	}
+}
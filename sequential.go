@@ -0,0 +1,34 @@
+package comb
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NewSequential returns a UUID in the same layout as NewTimeStampedUUID
+// save that its leading bytes hold seq in place of random data, most
+// significant bits first, wrapped around the version in byte 6 and the
+// variant in byte 8, so that a greater seq gives greater leading bytes.
+// There is no randomness at all, UUIDs are unique only so long as no seq
+// is used twice within a tick, and are trivially guessed, NewSequential
+// is intended for deterministic pipelines only.
+func NewSequential(seq uint64) (uuid.UUID, error) {
+	const fname = "NewSequential"
+	now, _, err := uuid.GetTime()
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", fname, err)
+	}
+	id, err := SetTimeStamp(uuid.Nil, 6, now, time.Millisecond/10)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", fname, err)
+	}
+
+	uint64ToBytes(id[:6], 6, seq>>16)
+	id[6] = byte(seq>>12) & versionMask
+	id[7] = byte(seq >> 4)
+	id[8] = byte(seq) & 0x0f
+	setRFCBits(&id)
+	return id, nil
+}
@@ -0,0 +1,34 @@
+package comb
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestNewSequential(t *testing.T) {
+	seqs := []uint64{0, 1, 2, 0x0f, 0x10, 0xfff, 0x1000, 0xffff, 0x10000, 1<<48 - 1, 1 << 48, ^uint64(0) - 1, ^uint64(0)}
+	var last []byte
+	for i, seq := range seqs {
+		id, err := NewSequential(seq)
+		if err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		if err := Validate(id); err != nil {
+			t.Errorf("%#x: did not expect an error: %v", seq, err)
+		}
+		if d := time.Since(ParseTime(id)); d > time.Second || d < -time.Second {
+			t.Errorf("%#x: want a time close to now got %v", seq, ParseTime(id))
+		}
+		if i > 0 && bytes.Compare(id[:10], last) <= 0 {
+			t.Errorf("%#x: want leading bytes greater than %x got %x", seq, last, id[:10])
+		}
+		last = append(last[:0], id[:10]...)
+	}
+
+	a, _ := NewSequential(7)
+	b, _ := NewSequential(7)
+	if !EqualIgnoringTime(a, b) {
+		t.Errorf("want the same leading bytes for the same seq got %v and %v", a, b)
+	}
+}
@@ -0,0 +1,39 @@
+package comb
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NewWithShard returns a UUID in the same layout as NewTimeStampedUUID
+// save that its first 2 bytes hold shard, big endian, making the UUID
+// routable to its shard.  This leaves 57 bits of random data in place of
+// 73, bytes 2 to 9 less the version and variant bits.
+func NewWithShard(shard uint16) (uuid.UUID, error) {
+	const fname = "NewWithShard"
+	now, _, err := uuid.GetTime()
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", fname, err)
+	}
+	id, err := SetTimeStamp(uuid.Nil, 6, now, time.Millisecond/10)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", fname, err)
+	}
+
+	uint64ToBytes(id[:2], 2, uint64(shard))
+	if _, err := io.ReadFull(rand.Reader, id[2:10]); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", fname, readError{err})
+	}
+	setRFCBits(&id)
+	return id, nil
+}
+
+// ReadShard returns the shard written into the first 2 bytes of a UUID
+// by NewWithShard.
+func ReadShard(id uuid.UUID) uint16 {
+	return uint16(bytesToUint64(id[:2], 2))
+}
@@ -0,0 +1,24 @@
+package comb
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewWithShard(t *testing.T) {
+	for _, shard := range []uint16{0, 1, 0x0102, 0xffff} {
+		id, err := NewWithShard(shard)
+		if err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		if got := ReadShard(id); got != shard {
+			t.Errorf("want %#x got %#x", shard, got)
+		}
+		if err := Validate(id); err != nil {
+			t.Error("did not expect an error:", err)
+		}
+		if d := time.Since(ParseTime(id)); d > time.Second || d < -time.Second {
+			t.Errorf("want a time close to now got %v", ParseTime(id))
+		}
+	}
+}
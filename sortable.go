@@ -0,0 +1,134 @@
+package comb
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NewSortableUUID returns a UUID with 6 bytes of timestamp data in its
+// first 6 bytes, followed by 10 bytes of cryptographically random data
+// of which 7 bits are used to set the rfc4122 variant and version, variant
+// future and version 6.  As the time stamp occupies the most significant
+// bytes, the byte order of the UUIDs is also their chronological order, to
+// a 10th of a millisecond.
+func NewSortableUUID() (uuid.UUID, error) {
+	now, _, err := uuid.GetTime()
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("NewSortableUUID: %w", err)
+	}
+	return CustomSortableUUID(rand.Reader, 6, now, time.Millisecond/10, true)
+}
+
+// CustomSortableUUID generates a uuid.UUID with its first nBytes set to a
+// time stamp of the given time resolution and the remaining bytes random
+// data.  nBytes must be between 1 and 8, the width of the uint64 in which
+// the time stamp is computed.  When rfc4122 is set the time stamp may not
+// exceed 6 bytes, as it would otherwise overwrite the version information.
+func CustomSortableUUID(r io.Reader, nBytes int, t uuid.Time, res time.Duration, rfc4122 bool) (uuid.UUID, error) {
+	var id uuid.UUID
+	const fname = "CustomSortableUUID"
+	fail := func(err error) (uuid.UUID, error) {
+		return id, fmt.Errorf("%s: %w", fname, err)
+	}
+
+	if err := checkWidth(nBytes, false); err != nil {
+		return fail(err)
+	}
+	if rfc4122 && nBytes > 6 {
+		return fail(fmt.Errorf("%w, %d overwrites the rfc4122 version, the maximum is 6",
+			ErrTooManyBytes, nBytes))
+	}
+
+	mask := timeStampMask(nBytes)
+	uint64ToBytes(id[:nBytes], nBytes, toTicks(t, res)&mask)
+
+	// Fill the remaining bytes with values from the io.Reader.
+	_, err := io.ReadFull(r, id[nBytes:])
+	if err != nil {
+		return fail(readError{err})
+	}
+
+	if rfc4122 {
+		setRFCBits(&id)
+	}
+
+	return id, nil
+}
+
+// ReadLeadingTimeStamp reads the time stamp that is set into a
+// SortableUUID.
+func ReadLeadingTimeStamp(id uuid.UUID) uint64 {
+	return ReadCustomLeadingTimeStamp(id, 6)
+}
+
+// ReadCustomLeadingTimeStamp reads n bytes from the most significant bit
+// of the uuid and returns the value contained there as an integer.  As
+// no more than 8 bytes fit into a uint64, for a larger nBytes only the
+// last 8 of the leading nBytes are read, the least significant bytes of a
+// wider big endian field, and an nBytes of less than 1 reads nothing and
+// returns 0.
+func ReadCustomLeadingTimeStamp(id uuid.UUID, nBytes int) uint64 {
+	if nBytes < 1 {
+		return 0
+	}
+	if nBytes > len(id) {
+		nBytes = len(id)
+	}
+	start := 0
+	if nBytes > maxTimeStampBytes {
+		start = nBytes - maxTimeStampBytes
+	}
+	return bytesToUint64(id[start:nBytes], nBytes-start)
+}
+
+// timeOrdered holds the last tick and counter used by NewTimeOrdered.
+var timeOrdered struct {
+	sync.Mutex
+	tick uint64
+	seq  uint64
+}
+
+// maxSeq is the largest value of the 12 bit counter of NewTimeOrdered.
+const maxSeq = 1<<12 - 1
+
+// NewTimeOrdered returns a UUID whose first 6 bytes hold the time stamp,
+// as for NewSortableUUID, followed by the version and a 12 bit counter in
+// bytes 6 and 7, then the variant and 61 bits of cryptographically random
+// data.  The counter is reset with each new tick of the clock and
+// incremented for each UUID generated within the same tick, so that the
+// byte order of the UUIDs generated within the process is their order of
+// generation, even within a tick.  Should more than 4096 UUIDs be wanted
+// within a tick, the time stamp is advanced into the next tick.
+func NewTimeOrdered() (uuid.UUID, error) {
+	const fname = "NewTimeOrdered"
+	var id uuid.UUID
+	now, _, err := uuid.GetTime()
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", fname, err)
+	}
+	if _, err := io.ReadFull(rand.Reader, id[8:]); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", fname, readError{err})
+	}
+
+	tick := toTicks(now, time.Millisecond/10) & timeStampMask(6)
+	timeOrdered.Lock()
+	if tick > timeOrdered.tick {
+		timeOrdered.tick, timeOrdered.seq = tick, 0
+	} else if timeOrdered.seq < maxSeq {
+		timeOrdered.seq++
+	} else {
+		timeOrdered.tick, timeOrdered.seq = timeOrdered.tick+1, 0
+	}
+	tick, seq := timeOrdered.tick, timeOrdered.seq
+	timeOrdered.Unlock()
+
+	uint64ToBytes(id[:6], 6, tick)
+	uint64ToBytes(id[6:8], 2, seq)
+	setRFCBits(&id)
+	return id, nil
+}
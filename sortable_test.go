@@ -0,0 +1,148 @@
+package comb
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	mrand "math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestSortableUUID(t *testing.T) {
+	const n = 100
+	const res = time.Millisecond / 10
+	now, _, err := uuid.GetTime()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+
+	want := make([]uuid.UUID, n)
+	for i := range want {
+		ut := now + uuid.Time(uint64(i)*ticks(res))
+		want[i], err = CustomSortableUUID(rand.Reader, 6, ut, res, true)
+		if err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		if want[i].Version() != 6 {
+			t.Errorf("want version 6 got %d", want[i].Version())
+		}
+		if ReadLeadingTimeStamp(want[i]) != toTicks(ut, res)&(1<<48-1) {
+			t.Errorf("want %d got %d", toTicks(ut, res), ReadLeadingTimeStamp(want[i]))
+		}
+	}
+
+	got := make([]uuid.UUID, n)
+	copy(got, want)
+	mrand.Shuffle(n, func(i, j int) { got[i], got[j] = got[j], got[i] })
+	sort.Slice(got, func(i, j int) bool {
+		return bytes.Compare(got[i][:], got[j][:]) < 0
+	})
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("%d: want %v got %v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestCustomSortableUUIDVersionOverlap(t *testing.T) {
+	_, err := CustomSortableUUID(rand.Reader, 7, 0, time.Millisecond, true)
+	if err == nil {
+		t.Error("expected an error")
+	}
+	_, err = CustomSortableUUID(rand.Reader, 7, 0, time.Millisecond, false)
+	if err != nil {
+		t.Error("did not expect an error:", err)
+	}
+}
+
+func TestCustomSortableUUIDWidth(t *testing.T) {
+	tests := []struct {
+		nBytes int
+		err    error
+	}{
+		{-1, ErrTooFewBytes},
+		{0, ErrTooFewBytes},
+		{1, nil},
+		{8, nil},
+		{9, ErrTooManyBytes},
+		{17, ErrTooManyBytes},
+	}
+	for _, test := range tests {
+		id, err := CustomSortableUUID(rand.Reader, test.nBytes, 0, time.Millisecond, false)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%d bytes: want %v got %v", test.nBytes, test.err, err)
+		}
+		if test.err != nil && id != uuid.Nil {
+			t.Errorf("%d bytes: want %v got %v", test.nBytes, uuid.Nil, id)
+		}
+	}
+}
+
+func TestReadCustomLeadingTimeStampWidth(t *testing.T) {
+	// A 10 byte big endian field holding a value in its last 8 bytes.
+	var id uuid.UUID
+	uint64ToBytes(id[2:10], 8, 0x0123456789abcdef)
+	if ts := ReadCustomLeadingTimeStamp(id, 10); ts != 0x0123456789abcdef {
+		t.Errorf("want %#x got %#x", 0x0123456789abcdef, ts)
+	}
+	for _, n := range []int{-1, 0} {
+		if ts := ReadCustomLeadingTimeStamp(id, n); ts != 0 {
+			t.Errorf("%d bytes: want 0 got %d", n, ts)
+		}
+	}
+	if ts := ReadCustomLeadingTimeStamp(id, 17); ts != ReadCustomLeadingTimeStamp(id, 16) {
+		t.Errorf("want %d got %d", ReadCustomLeadingTimeStamp(id, 16), ts)
+	}
+}
+
+func TestNewTimeOrdered(t *testing.T) {
+	const n = 10000
+	ids := make([]uuid.UUID, n)
+	for i := range ids {
+		var err error
+		ids[i], err = NewTimeOrdered()
+		if err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+	}
+	for i := 1; i < n; i++ {
+		if bytes.Compare(ids[i-1][:], ids[i][:]) >= 0 {
+			t.Fatalf("%d: want %v before %v", i, ids[i-1], ids[i])
+		}
+	}
+	if err := Validate(ids[0]); err != nil {
+		t.Error("did not expect an error:", err)
+	}
+	last := UUIDTimeToUnix(uuid.Time(ReadLeadingTimeStamp(ids[n-1]) * 1000))
+	if d := time.Since(last); d > time.Second || d < -time.Second {
+		t.Errorf("want a time close to now got %v", last)
+	}
+}
+
+func TestNewTimeOrderedExhaustedTick(t *testing.T) {
+	// Force the counter to its maximum in a tick far in the future.
+	timeOrdered.Lock()
+	saved, savedSeq := timeOrdered.tick, timeOrdered.seq
+	timeOrdered.tick, timeOrdered.seq = saved+1e9, maxSeq
+	timeOrdered.Unlock()
+	defer func() {
+		timeOrdered.Lock()
+		timeOrdered.tick, timeOrdered.seq = saved, savedSeq
+		timeOrdered.Unlock()
+	}()
+
+	id, err := NewTimeOrdered()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if got := ReadLeadingTimeStamp(id); got != saved+1e9+1 {
+		t.Errorf("want tick %d got %d", saved+1e9+1, got)
+	}
+	if seq := bytesToUint64(id[6:8], 2) & maxSeq; seq != 0 {
+		t.Errorf("want counter 0 got %d", seq)
+	}
+}
@@ -0,0 +1,86 @@
+package comb
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+// stream is an io.Reader of consecutive TimeStampedUUIDs.
+type stream struct {
+	r   io.Reader
+	buf uuid.UUID
+	off int // Offset of the unread bytes in buf.
+}
+
+// NewStream returns an io.Reader that fills each read with consecutive 16
+// byte TimeStampedUUIDs, each stamped with the current time and its random
+// data drawn from r.  A UUID that straddles the end of one read is
+// completed by the next.
+func NewStream(r io.Reader) io.Reader {
+	s := &stream{r: r}
+	s.off = len(s.buf)
+	return s
+}
+
+func (s *stream) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if s.off == len(s.buf) {
+			id, err := NewTimeStampedUUIDFrom(s.r)
+			if err != nil {
+				return n, fmt.Errorf("stream.Read: %w", err)
+			}
+			s.buf, s.off = id, 0
+		}
+		c := copy(p[n:], s.buf[s.off:])
+		s.off += c
+		n += c
+	}
+	return n, nil
+}
+
+// writeBatch is the largest number of UUIDs that WriteN buffers for a
+// single write.
+const writeBatch = 4096
+
+// WriteN generates n TimeStampedUUIDs and writes them to w as consecutive
+// 16 byte values, buffering up to 4096 UUIDs per call to w.Write.  It
+// returns the number of whole UUIDs written, which on a short write from w
+// may be less than n.  UUIDs in the same buffer share a time stamp, as do
+// those returned by NewBatch.
+func WriteN(w io.Writer, n int) (int, error) {
+	const fname = "WriteN"
+	if n < 0 {
+		return 0, fmt.Errorf("%s: %w, %d given", fname, ErrNegativeCount, n)
+	}
+	buf := make([]byte, 0, 16*minInt(n, writeBatch))
+	written := 0
+	for written < n {
+		ids, err := NewBatch(minInt(n-written, writeBatch))
+		if err != nil {
+			return written, fmt.Errorf("%s: %w", fname, err)
+		}
+		buf = buf[:0]
+		for _, id := range ids {
+			buf = append(buf, id[:]...)
+		}
+		c, err := w.Write(buf)
+		written += c / 16
+		if err == nil && c < len(buf) {
+			err = io.ErrShortWrite
+		}
+		if err != nil {
+			return written, fmt.Errorf("%s: %w", fname, err)
+		}
+	}
+	return written, nil
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
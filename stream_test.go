@@ -0,0 +1,123 @@
+package comb
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/google/uuid"
+)
+
+func TestNewStream(t *testing.T) {
+	const n = 100
+	// Reading in chunks of 7 bytes splits most UUIDs across reads.
+	s := NewStream(rand.Reader)
+	var buf bytes.Buffer
+	chunk := make([]byte, 7)
+	for buf.Len() < n*16 {
+		size := n*16 - buf.Len()
+		if size > len(chunk) {
+			size = len(chunk)
+		}
+		c, err := s.Read(chunk[:size])
+		if err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		buf.Write(chunk[:c])
+	}
+
+	for i := 0; i < n; i++ {
+		id, err := uuid.FromBytes(buf.Next(16))
+		if err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		if err := Validate(id); err != nil {
+			t.Errorf("%d: did not expect an error: %v", i, err)
+		}
+	}
+}
+
+func TestNewStreamOneByte(t *testing.T) {
+	b := make([]byte, 3*16)
+	_, err := io.ReadFull(iotest.OneByteReader(NewStream(rand.Reader)), b)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	for i := 0; i < len(b); i += 16 {
+		id, _ := uuid.FromBytes(b[i : i+16])
+		if !IsCombUUID(id) {
+			t.Errorf("%v: want a comb uuid", id)
+		}
+	}
+}
+
+func TestNewStreamShortRead(t *testing.T) {
+	s := NewStream(bytes.NewReader(make([]byte, 15)))
+	b := make([]byte, 32)
+	n, err := s.Read(b)
+	if n != 16 {
+		t.Errorf("want 16 got %d", n)
+	}
+	if !errors.Is(err, ErrShortRead) {
+		t.Errorf("want %q got %v", ErrShortRead, err)
+	}
+}
+
+func TestWriteN(t *testing.T) {
+	for _, n := range []int{0, 1, 100, writeBatch + 1} {
+		var buf bytes.Buffer
+		got, err := WriteN(&buf, n)
+		if err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		if got != n || buf.Len() != n*16 {
+			t.Errorf("want %d got %d uuids in %d bytes", n, got, buf.Len())
+		}
+		for buf.Len() > 0 {
+			id, err := uuid.FromBytes(buf.Next(16))
+			if err != nil {
+				t.Fatal("did not expect an error:", err)
+			}
+			if err := Validate(id); err != nil {
+				t.Error("did not expect an error:", err)
+			}
+		}
+	}
+}
+
+// limitWriter accepts up to n bytes, failing with errFull thereafter.
+type limitWriter struct {
+	n int
+}
+
+var errFull = errors.New("full")
+
+func (w *limitWriter) Write(p []byte) (int, error) {
+	if len(p) > w.n {
+		n := w.n
+		w.n = 0
+		return n, errFull
+	}
+	w.n -= len(p)
+	return len(p), nil
+}
+
+func TestWriteNNegative(t *testing.T) {
+	if _, err := WriteN(io.Discard, -1); !errors.Is(err, ErrNegativeCount) {
+		t.Errorf("want %q got %v", ErrNegativeCount, err)
+	}
+}
+
+func TestWriteNShortWrite(t *testing.T) {
+	// Room for two and a half UUIDs.
+	got, err := WriteN(&limitWriter{n: 40}, 10)
+	if !errors.Is(err, errFull) {
+		t.Errorf("want %q got %v", errFull, err)
+	}
+	if got != 2 {
+		t.Errorf("want 2 got %d", got)
+	}
+}
@@ -0,0 +1,42 @@
+package comb
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NewV7 returns a version 7 UUID as specified by RFC 9562, which
+// obsoletes rfc4122, so as to interoperate with other implementations.
+// Its leading 48 bits hold the number of milliseconds since the Unix
+// epoch, followed by the 4 bit version, 7, then 12 bits of random data,
+// the 2 bit variant, 10, and 62 more bits of random data.  Unlike the
+// layout of NewTimeStampedUUID the time stamp is in the most significant
+// bytes and so the UUIDs sort by time.
+func NewV7() (uuid.UUID, error) {
+	var id uuid.UUID
+	ms := uint64(time.Now().UnixMilli())
+	uint64ToBytes(id[:6], 6, ms)
+
+	if _, err := io.ReadFull(rand.Reader, id[6:]); err != nil {
+		return uuid.Nil, fmt.Errorf("NewV7: %w", readError{err})
+	}
+	id[6] = (id[6] & 0x0f) | 0x70 // Version 7
+	id[8] = (id[8] & 0x3f) | 0x80 // Variant is 10, RFC 9562
+	return id, nil
+}
+
+// ReadRFCv6Time returns the time held by a version 6 UUID as specified by
+// RFC 9562, as made by other implementations.  Such a UUID carries a 60
+// bit count of 100ns intervals since 15 Oct 1582 in its leading bytes,
+// most significant first, split around the version nibble of byte 6.  The
+// UUIDs of this package, though also marked as version 6, carry their
+// time stamp in their trailing bytes and are read with ReadTimeStamp and
+// ParseTime, neither function decodes the layout of the other.
+func ReadRFCv6Time(id uuid.UUID) time.Time {
+	ts := bytesToUint64(id[:6], 6)<<12 | uint64(id[6]&0x0f)<<8 | uint64(id[7])
+	return UUIDTimeToUnix(uuid.Time(ts))
+}
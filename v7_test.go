@@ -0,0 +1,49 @@
+package comb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewV7(t *testing.T) {
+	before := time.Now().UnixMilli()
+	id, err := NewV7()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	after := time.Now().UnixMilli()
+
+	// Parsing the string form checks the layout as seen by google/uuid.
+	parsed, err := uuid.Parse(id.String())
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if v := parsed.Version(); v != 7 {
+		t.Errorf("want version 7 got %d", v)
+	}
+	if v := parsed.Variant(); v != uuid.RFC4122 {
+		t.Errorf("want variant %v got %v", uuid.RFC4122, v)
+	}
+	ms := int64(bytesToUint64(parsed[:6], 6))
+	if ms < before || ms > after {
+		t.Errorf("want a time between %d and %d got %d", before, after, ms)
+	}
+	if IsCombUUID(id) {
+		t.Error("did not expect a version 7 uuid to validate as comb")
+	}
+}
+
+func TestReadRFCv6Time(t *testing.T) {
+	// The version 6 example of RFC 9562 appendix A.5.
+	id := uuid.MustParse("1EC9414C-232A-6B00-B3C8-9F6BDECED846")
+	want := time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)
+	if got := ReadRFCv6Time(id); !got.Equal(want) {
+		t.Errorf("want %v got %v", want, got)
+	}
+	// The trailing time stamp of this package decodes differently.
+	if got := ParseTime(id); got.Equal(want) {
+		t.Errorf("want a different time from ParseTime got %v", got)
+	}
+}
@@ -0,0 +1,96 @@
+package comb
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// The version nibble of byte 6 and the 3 variant bits of byte 8 set by
+// this package, with the masks of the bits of those bytes that they leave
+// untouched.
+const (
+	versionBits = 0x60 // Version 6
+	versionMask = 0x0f
+	variantBits = 0xe0 // Variant is 111, future
+	variantMask = 0x1f
+)
+
+// setRFCBits sets the version and variant bits of this package in id.
+func setRFCBits(id *uuid.UUID) {
+	id[6] = (id[6] & versionMask) | versionBits
+	id[8] = (id[8] & variantMask) | variantBits
+}
+
+// ApplyRFCBits returns id with the version and variant set by this
+// package, version 6 and variant future, 111, in bytes 6 and 8.
+func ApplyRFCBits(id uuid.UUID) uuid.UUID {
+	setRFCBits(&id)
+	return id
+}
+
+// ClearRFCBits returns id with the bits of bytes 6 and 8 that carry the
+// version and variant zeroed, leaving only the bits that are random or
+// hold the time stamp.
+func ClearRFCBits(id uuid.UUID) uuid.UUID {
+	id[6] &= versionMask
+	id[8] &= variantMask
+	return id
+}
+
+// IsCombUUID reports whether id carries the version and variant set by
+// this package, version 6 and variant future, 111.
+func IsCombUUID(id uuid.UUID) bool {
+	return id[6]&^versionMask == versionBits && id[8]&^variantMask == variantBits
+}
+
+// Validate returns an error describing which field does not match when id
+// does not carry the version and variant set by this package.
+func Validate(id uuid.UUID) error {
+	const fname = "Validate"
+	if v := id[6] &^ versionMask; v != versionBits {
+		return fmt.Errorf("%s: %w, version is %d not %d",
+			fname, ErrNotComb, v>>4, versionBits>>4)
+	}
+	if v := id[8] &^ variantMask; v != variantBits {
+		return fmt.Errorf("%s: %w, variant bits are %03b not %03b",
+			fname, ErrNotComb, v>>5, variantBits>>5)
+	}
+	return nil
+}
+
+// ValidateTimestamp returns an error wrapping ErrTimestampRange should the
+// decoded time stamp of a TimeStampedUUID fall outside [minTime, maxTime],
+// as would that of a uuid corrupted in storage whose version and variant
+// survived.  It checks neither the version nor the variant, see Validate.
+func ValidateTimestamp(id uuid.UUID, minTime, maxTime time.Time) error {
+	const fname = "ValidateTimestamp"
+	t := ParseTime(id)
+	if t.Before(minTime) || t.After(maxTime) {
+		return fmt.Errorf("%s: %w, %v is outside %v to %v",
+			fname, ErrTimestampRange, t, minTime, maxTime)
+	}
+	return nil
+}
+
+// IsFuture reports whether the time stamp of a TimeStampedUUID is later
+// than the current time by more than tolerance, as would be the case for
+// a uuid minted on a machine whose clock is badly set, or a forgery.
+func IsFuture(id uuid.UUID, tolerance time.Duration) bool {
+	return ParseTime(id).After(time.Now().Add(tolerance))
+}
+
+// Age returns the time elapsed since the time stamp of a TimeStampedUUID,
+// negative should it be stamped in the future.
+func Age(id uuid.UUID) time.Duration {
+	return time.Since(ParseTime(id))
+}
+
+// AgeCustom returns the time elapsed since the time stamp held in the last
+// nBytes of the uuid, at the resolution res from epoch, the zero time.Time
+// standing for 15 Oct 1582 as for NewDecoder.  The age is negative should
+// the uuid be stamped in the future.
+func AgeCustom(id uuid.UUID, nBytes int, res time.Duration, epoch time.Time) time.Duration {
+	return time.Since(NewDecoder(nBytes, res, epoch).Time(id))
+}
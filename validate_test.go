@@ -0,0 +1,167 @@
+package comb
+
+import (
+	"crypto/rand"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestValidate(t *testing.T) {
+	id, err := NewTimeStampedUUID()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if !IsCombUUID(id) {
+		t.Errorf("%v: want true got false", id)
+	}
+	if err := Validate(id); err != nil {
+		t.Error("did not expect an error:", err)
+	}
+
+	v4 := uuid.New()
+	if IsCombUUID(v4) {
+		t.Errorf("%v: want false got true", v4)
+	}
+	err = Validate(v4)
+	if err == nil || !strings.Contains(err.Error(), "version") {
+		t.Errorf("want a version error got %v", err)
+	}
+
+	// Correct version, rfc4122 variant.
+	id[8] = (id[8] & 0x3f) | 0x80
+	if IsCombUUID(id) {
+		t.Errorf("%v: want false got true", id)
+	}
+	err = Validate(id)
+	if err == nil || !strings.Contains(err.Error(), "variant") {
+		t.Errorf("want a variant error got %v", err)
+	}
+}
+
+func TestIsFuture(t *testing.T) {
+	future, err := NewTimeStampedUUIDAt(time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if !IsFuture(future, time.Minute) {
+		t.Error("want an hour ahead to be in the future with a minute of tolerance")
+	}
+	if IsFuture(future, 2*time.Hour) {
+		t.Error("want an hour ahead to be tolerated by two hours")
+	}
+
+	now, err := NewTimeStampedUUID()
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if IsFuture(now, time.Minute) {
+		t.Error("did not expect the current time to be in the future")
+	}
+}
+
+func TestRFCBits(t *testing.T) {
+	ids := []uuid.UUID{uuid.Nil, uuid.New()}
+	var ones uuid.UUID
+	for i := range ones {
+		ones[i] = 0xff
+	}
+	ids = append(ids, ones)
+
+	for _, id := range ids {
+		applied := ApplyRFCBits(id)
+		if !IsCombUUID(applied) {
+			t.Errorf("%v: want a comb uuid got %v", id, applied)
+		}
+		if again := ApplyRFCBits(applied); again != applied {
+			t.Errorf("%v: want %v got %v", id, applied, again)
+		}
+		cleared := ClearRFCBits(id)
+		if cleared[6]&0xf0 != 0 || cleared[8]&0xe0 != 0 {
+			t.Errorf("%v: want the version and variant bits cleared got %v", id, cleared)
+		}
+		if again := ClearRFCBits(cleared); again != cleared {
+			t.Errorf("%v: want %v got %v", id, cleared, again)
+		}
+		// Applying to the cleared uuid restores the applied one.
+		if got := ApplyRFCBits(cleared); got != applied {
+			t.Errorf("%v: want %v got %v", id, applied, got)
+		}
+	}
+
+	// Clearing leaves the random bits of bytes 6 and 8 and all others.
+	cleared := ClearRFCBits(ones)
+	for i, b := range cleared {
+		want := byte(0xff)
+		switch i {
+		case 6:
+			want = 0x0f
+		case 8:
+			want = 0x1f
+		}
+		if b != want {
+			t.Errorf("byte %d: want %#02x got %#02x", i, want, b)
+		}
+	}
+}
+
+func TestAge(t *testing.T) {
+	const res = time.Millisecond / 10
+	id, err := NewTimeStampedUUIDAt(time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if age := Age(id); age < time.Hour-time.Second || age > time.Hour+time.Second {
+		t.Errorf("want an age of about an hour got %v", age)
+	}
+	future, _ := NewTimeStampedUUIDAt(time.Now().Add(time.Hour))
+	if age := Age(future); age > -time.Hour+time.Second {
+		t.Errorf("want an age of about minus an hour got %v", age)
+	}
+
+	epoch := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	when := time.Now().Add(-time.Minute)
+	id, err = CustomTimeStampedUUID(rand.Reader, 5, UnixToUUIDTime(when)-UnixToUUIDTime(epoch), time.Millisecond, true)
+	if err != nil {
+		t.Fatal("did not expect an error:", err)
+	}
+	if age := AgeCustom(id, 5, time.Millisecond, epoch); age < time.Minute-time.Second || age > time.Minute+time.Second {
+		t.Errorf("want an age of about a minute got %v", age)
+	}
+	id, _ = CustomTimeStampedUUID(rand.Reader, 6, UnixToUUIDTime(when), res, true)
+	if age := AgeCustom(id, 6, res, time.Time{}); age < time.Minute-time.Second || age > time.Minute+time.Second {
+		t.Errorf("want an age of about a minute got %v", age)
+	}
+}
+
+func TestValidateTimestamp(t *testing.T) {
+	earliest := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	latest := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		when time.Time
+		ok   bool
+	}{
+		{earliest, true},
+		{time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC), true},
+		{latest, true},
+		{earliest.Add(-time.Millisecond), false},
+		{latest.Add(time.Millisecond), false},
+		{time.Date(2500, 1, 1, 0, 0, 0, 0, time.UTC), false},
+	}
+	for _, test := range tests {
+		id, err := NewTimeStampedUUIDAt(test.when)
+		if err != nil {
+			t.Fatal("did not expect an error:", err)
+		}
+		err = ValidateTimestamp(id, earliest, latest)
+		if test.ok && err != nil {
+			t.Errorf("%v: did not expect an error: %v", test.when, err)
+		}
+		if !test.ok && !errors.Is(err, ErrTimestampRange) {
+			t.Errorf("%v: want %q got %v", test.when, ErrTimestampRange, err)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package comb
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Variant selects the variant bits, the high bits of byte 8, set by
+// CustomVariantUUID.
+type Variant byte
+
+// Variants and the number of bits of byte 8 that each takes, leaving the
+// rest of the byte random.  Together with the 4 bit version a UUID in the
+// default layout keeps 75 random bits for VariantNCS, 74 for
+// VariantRFC4122 and 73 for VariantMicrosoft and VariantFuture.
+const (
+	VariantNCS       Variant = iota // 0xx, 1 bit.
+	VariantRFC4122                  // 10x, 2 bits.
+	VariantMicrosoft                // 110, 3 bits.
+	VariantFuture                   // 111, 3 bits, that of NewTimeStampedUUID.
+)
+
+// valid reports whether v is one of the variants above.
+func (v Variant) valid() bool {
+	return v <= VariantFuture
+}
+
+// apply sets the variant bits of b, v being valid.
+func (v Variant) apply(b byte) byte {
+	switch v {
+	case VariantNCS:
+		return b & 0x7f
+	case VariantRFC4122:
+		return (b & 0x3f) | 0x80
+	case VariantMicrosoft:
+		return (b & 0x1f) | 0xc0
+	}
+	return (b & variantMask) | variantBits
+}
+
+// CustomVariantUUID generates a uuid.UUID as does CustomTimeStampedUUID
+// with rfc4122 set, version 6, save that the variant is v, so as to match
+// the expectations of an external system.  Only VariantFuture validates
+// as a UUID of this package with IsCombUUID.  An unknown v returns an
+// error wrapping ErrUnknownVariant before anything is read from r.
+func CustomVariantUUID(r io.Reader, nBytes int, t uuid.Time, res time.Duration, v Variant) (uuid.UUID, error) {
+	const fname = "CustomVariantUUID"
+	if !v.valid() {
+		return uuid.Nil, fmt.Errorf("%s: %w %d", fname, ErrUnknownVariant, v)
+	}
+	if err := checkWidth(nBytes, true); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", fname, err)
+	}
+	id, err := CustomTimeStampedUUID(r, nBytes, t, res, false)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", fname, err)
+	}
+	id[6] = (id[6] & versionMask) | versionBits
+	id[8] = v.apply(id[8])
+	return id, nil
+}
@@ -0,0 +1,58 @@
+package comb
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestCustomVariantUUID(t *testing.T) {
+	tests := []struct {
+		v     Variant
+		mask  byte
+		want  byte
+		uuidV uuid.Variant
+	}{
+		{VariantNCS, 0x80, 0x00, uuid.Reserved},
+		{VariantRFC4122, 0xc0, 0x80, uuid.RFC4122},
+		{VariantMicrosoft, 0xe0, 0xc0, uuid.Microsoft},
+		{VariantFuture, 0xe0, 0xe0, uuid.Future},
+	}
+	for _, fill := range []byte{0x00, 0xff} {
+		for _, test := range tests {
+			r := bytes.NewReader(bytes.Repeat([]byte{fill}, 10))
+			id, err := CustomVariantUUID(r, 6, 0, time.Millisecond/10, test.v)
+			if err != nil {
+				t.Fatal("did not expect an error:", err)
+			}
+			if id[8]&test.mask != test.want {
+				t.Errorf("variant %d: want high bits %08b got %08b", test.v, test.want, id[8]&test.mask)
+			}
+			// The remaining bits of byte 8 are the reader's.
+			if id[8]&^test.mask != fill&^test.mask {
+				t.Errorf("variant %d: want low bits %08b got %08b", test.v, fill&^test.mask, id[8]&^test.mask)
+			}
+			if id.Version() != 6 {
+				t.Errorf("variant %d: want version 6 got %d", test.v, id.Version())
+			}
+			if id.Variant() != test.uuidV {
+				t.Errorf("variant %d: want %v got %v", test.v, test.uuidV, id.Variant())
+			}
+			if IsCombUUID(id) != (test.v == VariantFuture) {
+				t.Errorf("variant %d: unexpected IsCombUUID %t", test.v, IsCombUUID(id))
+			}
+		}
+	}
+
+	// An unknown variant is rejected without consuming the random data.
+	r := bytes.NewReader(make([]byte, 10))
+	if _, err := CustomVariantUUID(r, 6, 0, time.Millisecond, Variant(9)); !errors.Is(err, ErrUnknownVariant) {
+		t.Errorf("want %q got %v", ErrUnknownVariant, err)
+	}
+	if r.Len() != 10 {
+		t.Errorf("want 10 unread bytes got %d", r.Len())
+	}
+}